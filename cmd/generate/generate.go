@@ -11,6 +11,7 @@ import (
 	"github.com/mvoorberg/sqlxgen/internal/utils"
 	"github.com/mvoorberg/sqlxgen/internal/utils/fs"
 	"github.com/mvoorberg/sqlxgen/internal/utils/writer"
+	_ "modernc.org/sqlite"
 )
 
 func main() {
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mvoorberg/sqlxgen/internal/config"
+	"github.com/mvoorberg/sqlxgen/internal/utils"
+	"github.com/mvoorberg/sqlxgen/internal/utils/writer"
+	"github.com/spf13/cobra"
+)
+
+func checkCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Check that the generated code is up to date with sqlxgen config",
+		Run: func(cmd *cobra.Command, _ []string) {
+			sqlxGenAltPath, err := cmd.Flags().GetString("config")
+
+			if err != nil {
+				utils.ExitWithError(err)
+
+				return
+			}
+
+			offline, err := cmd.Flags().GetBool("offline")
+
+			if err != nil {
+				utils.ExitWithError(err)
+
+				return
+			}
+
+			stale, err := runCheck(config.SqlxGenArgs{SqlxAltPath: sqlxGenAltPath, Offline: offline})
+
+			if err != nil {
+				utils.ExitWithError(err)
+
+				return
+			}
+
+			if stale {
+				fmt.Println("generated code is stale, run sqlxgen generate")
+
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.
+		PersistentFlags().
+		String("config", "", "path to sqlxgen config")
+
+	cmd.
+		PersistentFlags().
+		Bool("offline", false, "generate from the snapshot in sqlxgen.lock.json, without a database")
+
+	return cmd
+}
+
+func runCheck(args config.SqlxGenArgs) (bool, error) {
+	cws := writer.NewCheckWriters()
+
+	args.WriterCreator = cws.Creator
+
+	err := generateWith(args)
+
+	if err != nil {
+		return false, err
+	}
+
+	return cws.Report(os.Stdout)
+}
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"io"
 	"os"
 
 	_ "github.com/go-sql-driver/mysql"
@@ -11,6 +12,7 @@ import (
 	"github.com/mvoorberg/sqlxgen/internal/utils/fs"
 	"github.com/mvoorberg/sqlxgen/internal/utils/writer"
 	"github.com/spf13/cobra"
+	_ "modernc.org/sqlite"
 )
 
 func generateCmd() *cobra.Command {
@@ -27,7 +29,29 @@ func generateCmd() *cobra.Command {
 				return
 			}
 
-			err = runGenerate(sqlxGenAltPath)
+			offline, err := cmd.Flags().GetBool("offline")
+
+			if err != nil {
+				utils.ExitWithError(err)
+
+				return
+			}
+
+			saveSnapshot, err := cmd.Flags().GetBool("save-snapshot")
+
+			if err != nil {
+				utils.ExitWithError(err)
+
+				return
+			}
+
+			err = runGenerate(
+				config.SqlxGenArgs{
+					SqlxAltPath:  sqlxGenAltPath,
+					Offline:      offline,
+					SaveSnapshot: saveSnapshot,
+				},
+			)
 
 			if err == nil {
 				return
@@ -41,37 +65,61 @@ func generateCmd() *cobra.Command {
 		PersistentFlags().
 		String("config", "", "path to sqlxgen config")
 
+	cmd.
+		PersistentFlags().
+		Bool("offline", false, "generate from the snapshot in sqlxgen.lock.json, without a database")
+
+	cmd.
+		PersistentFlags().
+		Bool("save-snapshot", false, "save the introspection to sqlxgen.lock.json for --offline")
+
 	return cmd
 }
 
-func runGenerate(sqlxGenAltPath string) error {
-	workingDir, err := os.Getwd()
+func runGenerate(args config.SqlxGenArgs) error {
+	args.WriterCreator = writer.NewFileWriter
+
+	return generateWith(args)
+}
+
+// generateWith runs the full generation, handing every generated file to
+// args.WriterCreator.
+func generateWith(args config.SqlxGenArgs) error {
+	sqlxGenCfg, err := newSqlxGen(args, nil)
 
 	if err != nil {
 		return err
 	}
 
-	fd := fs.NewFileDiscovery()
+	return sqlxGenCfg.Generate()
+}
+
+// newSqlxGen loads the config from the working directory, logs go to
+// logWriter when set.
+func newSqlxGen(args config.SqlxGenArgs, logWriter io.Writer) (*config.SqlxGen, error) {
+	workingDir, err := os.Getwd()
+
+	if err != nil {
+		return nil, err
+	}
+
+	args.Connect = sqlx.Connect
 
-	wc := writer.NewFileWriter
+	args.Fd = fs.NewFileDiscovery()
 
-	connect := sqlx.Connect
+	args.WorkingDir = workingDir
 
-	sqlxGenCfg, err := config.NewSqlxGen(
-		config.SqlxGenArgs{
-			Connect:       connect,
-			Fd:            fd,
-			WriterCreator: wc,
-			WorkingDir:    workingDir,
-			SqlxAltPath:   sqlxGenAltPath,
-		},
-	)
+	sqlxGenCfg, err := config.NewSqlxGen(args)
 
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if logWriter != nil {
+		sqlxGenCfg.LogArgs.Writer = logWriter
 	}
 
 	sqlxGenCfg.InitLogger()
 
-	return sqlxGenCfg.Generate()
+	return sqlxGenCfg, nil
 }
@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/joomcode/errorx"
+	"github.com/mvoorberg/sqlxgen/internal/config"
+	"github.com/mvoorberg/sqlxgen/internal/generate/plugin"
+	"github.com/mvoorberg/sqlxgen/internal/utils"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+func inspectCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inspect",
+		Short: "Print the introspected tables and queries with their inferred Go types",
+		Run: func(cmd *cobra.Command, _ []string) {
+			err := runInspect(cmd)
+
+			if err == nil {
+				return
+			}
+
+			utils.ExitWithError(err)
+		},
+	}
+
+	cmd.
+		PersistentFlags().
+		String("config", "", "path to sqlxgen config")
+
+	cmd.
+		PersistentFlags().
+		String("name", "", "name of the config to inspect, all configs by default")
+
+	cmd.
+		PersistentFlags().
+		String("format", "json", "output format, json or yaml")
+
+	cmd.
+		PersistentFlags().
+		String("output", "", "file to write to, stdout by default")
+
+	return cmd
+}
+
+func runInspect(cmd *cobra.Command) error {
+	sqlxGenAltPath, err := cmd.Flags().GetString("config")
+
+	if err != nil {
+		return err
+	}
+
+	name, err := cmd.Flags().GetString("name")
+
+	if err != nil {
+		return err
+	}
+
+	format, err := cmd.Flags().GetString("format")
+
+	if err != nil {
+		return err
+	}
+
+	output, err := cmd.Flags().GetString("output")
+
+	if err != nil {
+		return err
+	}
+
+	if format != "json" && format != "yaml" {
+		return errorx.IllegalArgument.New("unsupported format %s, use json or yaml", format)
+	}
+
+	// stdout is for the inspection
+	sqlxGenCfg, err := newSqlxGen(config.SqlxGenArgs{SqlxAltPath: sqlxGenAltPath}, os.Stderr)
+
+	if err != nil {
+		return err
+	}
+
+	requests, err := sqlxGenCfg.Inspect(name)
+
+	if err != nil {
+		return err
+	}
+
+	content, err := marshalInspection(requests, format)
+
+	if err != nil {
+		return err
+	}
+
+	if output != "" {
+		return os.WriteFile(output, content, 0644)
+	}
+
+	_, err = os.Stdout.Write(content)
+
+	return err
+}
+
+// marshalInspection keeps the json field names and their order in yaml, by
+// reading the json as a yaml document.
+func marshalInspection(requests []plugin.Request, format string) ([]byte, error) {
+	content, err := json.MarshalIndent(requests, "", "  ")
+
+	if err != nil {
+		return nil, errorx.InternalError.Wrap(err, "unable to marshal inspection")
+	}
+
+	if format == "json" {
+		return append(content, '\n'), nil
+	}
+
+	var node yaml.Node
+
+	err = yaml.Unmarshal(content, &node)
+
+	if err != nil {
+		return nil, errorx.InternalError.Wrap(err, "unable to convert inspection to yaml")
+	}
+
+	blockStyle(&node)
+
+	return yaml.Marshal(&node)
+}
+
+// blockStyle drops the flow style and the quotes json comes with.
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
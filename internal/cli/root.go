@@ -8,7 +8,7 @@ func RootCmd(version string) *cobra.Command {
 	root := &cobra.Command{
 		Use:   "sqlxgen",
 		Short: "sqlxgen is a code generator for sqlx",
-		Long: `sqlxgen is a code generator for sqlx, with support for both postgres and mysql.
+		Long: `sqlxgen is a code generator for sqlx, with support for postgres, mysql and sqlite.
 1. introspect database schema and generate models
 2. introspect sql queries in your project and generates input and output models
 `,
@@ -18,6 +18,10 @@ func RootCmd(version string) *cobra.Command {
 
 	root.AddCommand(generateCmd())
 
+	root.AddCommand(checkCmd())
+
+	root.AddCommand(inspectCmd())
+
 	root.AddCommand(versionCmd(version))
 
 	return root
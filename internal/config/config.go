@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/jmoiron/sqlx"
@@ -12,10 +14,15 @@ import (
 	"github.com/mvoorberg/sqlxgen/internal/generate"
 	mysqlgen "github.com/mvoorberg/sqlxgen/internal/generate/mysql"
 	pggen "github.com/mvoorberg/sqlxgen/internal/generate/pg"
+	"github.com/mvoorberg/sqlxgen/internal/generate/plugin"
+	sqlitegen "github.com/mvoorberg/sqlxgen/internal/generate/sqlite"
 	gentypes "github.com/mvoorberg/sqlxgen/internal/generate/types"
 	i "github.com/mvoorberg/sqlxgen/internal/introspect"
+	"github.com/mvoorberg/sqlxgen/internal/introspect/ddl"
 	mysqlintrospect "github.com/mvoorberg/sqlxgen/internal/introspect/mysql"
 	pgintrospect "github.com/mvoorberg/sqlxgen/internal/introspect/pg"
+	"github.com/mvoorberg/sqlxgen/internal/introspect/snapshot"
+	sqliteintrospect "github.com/mvoorberg/sqlxgen/internal/introspect/sqlite"
 	"github.com/mvoorberg/sqlxgen/internal/utils/array"
 	"github.com/mvoorberg/sqlxgen/internal/utils/fs"
 	"github.com/mvoorberg/sqlxgen/internal/utils/writer"
@@ -29,6 +36,14 @@ type Config struct {
 	Source   *types.Source   `json:"source" yaml:"source"`
 	Gen      *types.Gen      `json:"gen" yaml:"gen"`
 	Options  *types.Option   `json:"options" yaml:"options"`
+	// Overrides of the inferred Go types, after the global ones
+	Overrides []types.Override `json:"overrides,omitempty" yaml:"overrides"`
+	Templates *types.Templates `json:"templates,omitempty" yaml:"templates"`
+	Plugins   []types.Plugin   `json:"plugins,omitempty" yaml:"plugins"`
+	// snapshot replaces the database when generating offline
+	snapshot *snapshot.Config
+	// recording receives the introspection when saving a snapshot
+	recording *snapshot.Config
 }
 
 func (c *Config) String() string {
@@ -40,6 +55,9 @@ func (c *Config) String() string {
 			fmt.Sprintf("Source: %v", c.Source),
 			fmt.Sprintf("Gen: %v", c.Gen),
 			fmt.Sprintf("Options: %v", c.Options),
+			fmt.Sprintf("Overrides: %v", c.Overrides),
+			fmt.Sprintf("Templates: %v", c.Templates),
+			fmt.Sprintf("Plugins: %v", c.Plugins),
 		},
 		", ",
 	)
@@ -53,8 +71,65 @@ func (c *Config) Generate(
 	writerCreator writer.Creator,
 	workDir string,
 ) error {
+	return c.withTx(
+		connect,
+		func(tx *sqlx.Tx) error {
+			return c.generateEngine(*c.Engine, fd, writerCreator, tx, workDir)
+		},
+	)
+}
+
+// Inspect introspects the tables and queries, and infers their Go types,
+// like Generate does without generating anything.
+func (c *Config) Inspect(
+	connect Connect,
+	fd fs.FileDiscovery,
+	workDir string,
+) (plugin.Request, error) {
+	var request plugin.Request
+
+	err := c.withTx(
+		connect,
+		func(tx *sqlx.Tx) error {
+			introspect, translate, err := c.engine(fd)
+
+			if err != nil {
+				return err
+			}
+
+			gen, err := c.newGenerate(nil, introspect, translate, tx, workDir)
+
+			if err != nil {
+				return err
+			}
+
+			request, err = gen.Inspect()
+
+			return err
+		},
+	)
+
+	return request, err
+}
+
+// withTx runs fn in a transaction that is rolled back, tx is nil offline and
+// when the tables are read from ddl files and there are no queries.
+func (c *Config) withTx(connect Connect, fn func(tx *sqlx.Tx) error) error {
 	engine := *c.Engine
 
+	if c.snapshot != nil {
+		slog.Debug("reading tables and queries from the snapshot, skipping database connection")
+
+		return fn(nil)
+	}
+
+	// tables read from ddl files only need a database to introspect queries
+	if c.Source.Models.HasDdl() && len(c.Source.Queries.Paths) == 0 {
+		slog.Debug("reading tables from ddl files, skipping database connection")
+
+		return fn(nil)
+	}
+
 	slog.Debug("connecting to database")
 
 	dbUrl, err := c.Database.GetUrl(engine)
@@ -101,6 +176,16 @@ func (c *Config) Generate(
 		slog.Debug("rolled back transaction")
 	}(tx)
 
+	return fn(tx)
+}
+
+func (c *Config) generateEngine(
+	engine string,
+	fd fs.FileDiscovery,
+	writerCreator writer.Creator,
+	tx *sqlx.Tx,
+	workDir string,
+) error {
 	if engine == "postgres" {
 		return c.generatePg(fd, writerCreator, tx, workDir)
 	}
@@ -109,15 +194,48 @@ func (c *Config) Generate(
 		return c.generateMysql(fd, writerCreator, tx, workDir)
 	}
 
+	if engine == "sqlite" {
+		return c.generateSqlite(fd, writerCreator, tx, workDir)
+	}
+
 	return errorx.IllegalArgument.New("unsupported engine %s", engine)
 }
 
+// engine returns the introspection and the translation of the engine of
+// the config.
+func (c *Config) engine(fd fs.FileDiscovery) (i.Introspect, gentypes.Translate, error) {
+	switch *c.Engine {
+	case "postgres":
+		introspect, translate := c.pgEngine(fd)
+
+		return introspect, translate, nil
+
+	case "mysql":
+		introspect, translate := c.mysqlEngine(fd)
+
+		return introspect, translate, nil
+
+	case "sqlite":
+		introspect, translate := c.sqliteEngine(fd)
+
+		return introspect, translate, nil
+	}
+
+	return nil, nil, errorx.IllegalArgument.New("unsupported engine %s", *c.Engine)
+}
+
 func (c *Config) generatePg(
 	fd fs.FileDiscovery,
 	writerCreator writer.Creator,
 	tx *sqlx.Tx,
 	workDir string,
 ) error {
+	pgi, pt := c.pgEngine(fd)
+
+	return c.generate(writerCreator, pgi, pt, tx, workDir)
+}
+
+func (c *Config) pgEngine(fd fs.FileDiscovery) (i.Introspect, gentypes.Translate) {
 	pgi := pgintrospect.NewIntrospect(
 		fd,
 		pgintrospect.IntrospectArgs{
@@ -132,7 +250,7 @@ func (c *Config) generatePg(
 
 	pt := pggen.NewTranslate()
 
-	return c.generate(writerCreator, pgi, pt, tx, workDir)
+	return c.withSnapshot(fd, c.withDdl(fd, pgi)), pt
 }
 
 func (c *Config) generateMysql(
@@ -141,6 +259,12 @@ func (c *Config) generateMysql(
 	tx *sqlx.Tx,
 	workDir string,
 ) error {
+	mysqli, mt := c.mysqlEngine(fd)
+
+	return c.generate(writerCreator, mysqli, mt, tx, workDir)
+}
+
+func (c *Config) mysqlEngine(fd fs.FileDiscovery) (i.Introspect, gentypes.Translate) {
 	mysqli := mysqlintrospect.NewIntrospect(
 		fd,
 		mysqlintrospect.IntrospectArgs{
@@ -155,7 +279,76 @@ func (c *Config) generateMysql(
 
 	mt := mysqlgen.NewTranslate()
 
-	return c.generate(writerCreator, mysqli, mt, tx, workDir)
+	return c.withSnapshot(fd, c.withDdl(fd, mysqli)), mt
+}
+
+func (c *Config) generateSqlite(
+	fd fs.FileDiscovery,
+	writerCreator writer.Creator,
+	tx *sqlx.Tx,
+	workDir string,
+) error {
+	sqlitei, st := c.sqliteEngine(fd)
+
+	return c.generate(writerCreator, sqlitei, st, tx, workDir)
+}
+
+func (c *Config) sqliteEngine(fd fs.FileDiscovery) (i.Introspect, gentypes.Translate) {
+	sqlitei := sqliteintrospect.NewIntrospect(
+		fd,
+		sqliteintrospect.IntrospectArgs{
+			Schemas:         c.Source.Models.Schemas,
+			TableInclusions: c.Source.Models.Include,
+			TableExclusions: c.Source.Models.Exclude,
+			QueryDirs:       c.Source.Queries.Paths,
+			QueryInclusions: c.Source.Queries.Include,
+			QueryExclusions: c.Source.Queries.Exclude,
+		},
+	)
+
+	st := sqlitegen.NewTranslate()
+
+	return c.withSnapshot(fd, c.withDdl(fd, sqlitei)), st
+}
+
+// withDdl reads the tables from the configured ddl files when there are
+// any, the queries are still introspected by the engine.
+func (c *Config) withDdl(fd fs.FileDiscovery, introspect i.Introspect) i.Introspect {
+	if !c.Source.Models.HasDdl() {
+		return introspect
+	}
+
+	return ddl.NewIntrospect(
+		fd,
+		ddl.IntrospectArgs{
+			Engine:          *c.Engine,
+			Paths:           c.Source.Models.Ddl,
+			Schemas:         c.Source.Models.Schemas,
+			TableInclusions: c.Source.Models.Include,
+			TableExclusions: c.Source.Models.Exclude,
+		},
+		introspect,
+	)
+}
+
+// withSnapshot reads the tables and queries from the snapshot when
+// generating offline, and records them when saving a snapshot.
+func (c *Config) withSnapshot(fd fs.FileDiscovery, introspect i.Introspect) i.Introspect {
+	args := snapshot.IntrospectArgs{
+		QueryDirs:       c.Source.Queries.Paths,
+		QueryInclusions: c.Source.Queries.Include,
+		QueryExclusions: c.Source.Queries.Exclude,
+	}
+
+	if c.snapshot != nil {
+		return snapshot.NewIntrospect(fd, args, *c.snapshot)
+	}
+
+	if c.recording != nil {
+		return snapshot.NewRecorder(fd, args, introspect, c.recording)
+	}
+
+	return introspect
 }
 
 func (c *Config) generate(
@@ -165,12 +358,49 @@ func (c *Config) generate(
 	tx *sqlx.Tx,
 	workDir string,
 ) error {
+	gen, err := c.newGenerate(writerCreator, introspect, translate, tx, workDir)
+
+	if err != nil {
+		return err
+	}
+
+	return gen.Generate()
+}
+
+// newGenerate introspects the tables and queries to generate.
+func (c *Config) newGenerate(
+	writerCreator writer.Creator,
+	introspect i.Introspect,
+	translate gentypes.Translate,
+	tx *sqlx.Tx,
+	workDir string,
+) (generate.Generate, error) {
+	translate, err := gentypes.NewOverrideTranslate(translate, c.typeOverrides())
+
+	if err != nil {
+		return generate.Generate{}, errorx.Decorate(err, "invalid type overrides for %s", *c.Name)
+	}
+
+	translate = gentypes.NewJsonTranslate(translate)
+
+	translate, err = gentypes.NewFieldStyleTranslate(translate, c.fieldStyle())
+
+	if err != nil {
+		return generate.Generate{}, errorx.Decorate(err, "invalid options for %s", *c.Name)
+	}
+
+	templates, err := c.loadTemplates(workDir)
+
+	if err != nil {
+		return generate.Generate{}, errorx.Decorate(err, "invalid templates for %s", *c.Name)
+	}
+
 	slog.Debug("introspecting database")
 
 	tables, err := introspect.IntrospectSchema(tx)
 
 	if err != nil {
-		return err
+		return generate.Generate{}, err
 	}
 
 	tableNames := array.Map(
@@ -187,7 +417,7 @@ func (c *Config) generate(
 	queries, err := introspect.IntrospectQueries(tx)
 
 	if err != nil {
-		return err
+		return generate.Generate{}, err
 	}
 
 	queryNames := array.Map(
@@ -201,7 +431,7 @@ func (c *Config) generate(
 	tmpOpts, err := json.Marshal(c.Options)
 	if err != nil {
 		slog.Error("failed to marshal options", "error", err)
-		return err
+		return generate.Generate{}, err
 	}
 	json.Unmarshal(tmpOpts, &opts)
 	slog.Info("using options", "options", opts)
@@ -217,15 +447,13 @@ func (c *Config) generate(
 		Queries:         queries,
 		Translate:       translate,
 		Options:         opts,
+		Templates:       templates,
+		Name:            *c.Name,
+		Engine:          *c.Engine,
+		Plugins:         c.plugins(),
 	}
 
-	err = gen.Generate()
-
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return gen, nil
 }
 
 func (c *Config) Merge(other *Config) *Config {
@@ -252,7 +480,139 @@ func (c *Config) Merge(other *Config) *Config {
 	c.Gen = c.Gen.Merge(other.Gen)
 	c.Options = c.Options.Merge(other.Options)
 
+	if other.Overrides != nil {
+		c.Overrides = other.Overrides
+	}
+
+	c.Templates = c.Templates.Merge(other.Templates)
+
+	if other.Plugins != nil {
+		c.Plugins = other.Plugins
+	}
+
 	return c
 }
 
+func (c *Config) fieldStyle() string {
+	if c.Options == nil || c.Options.FieldStyle == nil {
+		return ""
+	}
+
+	return *c.Options.FieldStyle
+}
+
+func (c *Config) typeOverrides() []gentypes.Override {
+	return array.Map(
+		c.Overrides,
+		func(o types.Override, _ int) gentypes.Override {
+			return gentypes.Override{
+				DbType: o.DbType,
+				Column: o.Column,
+				GoType: o.GoType,
+				Json:   o.Json,
+			}
+		},
+	)
+}
+
+func (c *Config) plugins() []plugin.Plugin {
+	return array.Map(
+		c.Plugins,
+		func(p types.Plugin, _ int) plugin.Plugin {
+			return plugin.Plugin{
+				Name:    p.Name,
+				Command: p.Command,
+				Args:    p.Args,
+				Options: p.Options,
+			}
+		},
+	)
+}
+
+// loadTemplates reads the configured template files, relative paths are
+// relative to the project directory.
+func (c *Config) loadTemplates(workDir string) (generate.Templates, error) {
+	if c.Templates == nil {
+		return generate.Templates{}, nil
+	}
+
+	load := func(p string) (string, error) {
+		if p == "" {
+			return "", nil
+		}
+
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(workDir, p)
+		}
+
+		content, err := os.ReadFile(p)
+
+		if err != nil {
+			return "", errorx.InitializationFailed.Wrap(err, "unable to read template %s", p)
+		}
+
+		return string(content), nil
+	}
+
+	loadExtras := func(extras []types.ExtraTemplate) ([]gentypes.ExtraTemplate, error) {
+		loaded := make([]gentypes.ExtraTemplate, len(extras))
+
+		for idx, extra := range extras {
+			if extra.Path == "" || extra.FileName == "" {
+				return nil, errorx.IllegalArgument.New("extra template %d needs a path and a fileName", idx)
+			}
+
+			content, err := load(extra.Path)
+
+			if err != nil {
+				return nil, err
+			}
+
+			loaded[idx] = gentypes.ExtraTemplate{
+				Name:     extra.Path,
+				Content:  content,
+				FileName: extra.FileName,
+			}
+		}
+
+		return loaded, nil
+	}
+
+	var templates generate.Templates
+
+	var err error
+
+	templates.Model, err = load(c.Templates.Model)
+
+	if err != nil {
+		return generate.Templates{}, err
+	}
+
+	templates.Query, err = load(c.Templates.Query)
+
+	if err != nil {
+		return generate.Templates{}, err
+	}
+
+	templates.Store, err = load(c.Templates.Store)
+
+	if err != nil {
+		return generate.Templates{}, err
+	}
+
+	templates.Tables, err = loadExtras(c.Templates.Tables)
+
+	if err != nil {
+		return generate.Templates{}, err
+	}
+
+	templates.Queries, err = loadExtras(c.Templates.Queries)
+
+	if err != nil {
+		return generate.Templates{}, err
+	}
+
+	return templates, nil
+}
+
 type Connect func(engine string, connectionUrl string) (*sqlx.DB, error)
@@ -5,13 +5,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"os/exec"
 	"path"
+	"strings"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/bradleyjkemp/cupaloy"
 	"github.com/jinzhu/inflection"
 	"github.com/jmoiron/sqlx"
+	"github.com/mvoorberg/sqlxgen/internal/config/types"
+	"github.com/mvoorberg/sqlxgen/internal/generate/plugin"
+	"github.com/mvoorberg/sqlxgen/internal/introspect"
 	"github.com/mvoorberg/sqlxgen/internal/utils"
 	"github.com/mvoorberg/sqlxgen/internal/utils/array"
 	"github.com/mvoorberg/sqlxgen/internal/utils/casing"
@@ -225,7 +230,11 @@ func TestConfig_GeneratePg(t *testing.T) {
 
 	assert.NoError(t, err)
 
-	storePen, modelPens, queryPens := mw.Writers[0], mw.Writers[1:1+len(models)], mw.Writers[1+len(models):]
+	storePen, copyPen := mw.Writers[0], mw.Writers[1]
+
+	modelPens, queryPens := mw.Writers[2:2+len(models)], mw.Writers[2+len(models):]
+
+	assert.Equal(t, len(queryMetas), len(queryPens))
 
 	t.Run("store", func(t *testing.T) {
 		pen := storePen
@@ -236,116 +245,709 @@ func TestConfig_GeneratePg(t *testing.T) {
 
 		got := pen.Content
 
-		cupaloy.SnapshotT(t, got)
-	})
+		cupaloy.SnapshotT(t, got)
+	})
+
+	t.Run("copy", func(t *testing.T) {
+		pen := copyPen
+
+		fullPath := path.Join(workDir, "gen/pg/store/copy.gen.go")
+
+		assert.Equal(t, fullPath, pen.FullPath)
+
+		got := pen.Content
+
+		cupaloy.SnapshotT(t, got)
+	})
+
+	for i, pen := range modelPens {
+		modelName := inflection.Singular(models[i])
+
+		t.Run(modelName, func(t *testing.T) {
+			modelSnake, err := casing.SnakeCase(modelName)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			genFileName := fmt.Sprintf("%s.gen.go", modelSnake)
+
+			fullPath := path.Join(workDir, "gen/pg/models", genFileName)
+
+			assert.Equal(t, fullPath, pen.FullPath)
+
+			got := pen.Content
+
+			cupaloy.SnapshotT(t, got)
+		})
+	}
+
+	for i, pen := range queryPens {
+		qm := queryMetas[i]
+
+		t.Run(qm.name, func(t *testing.T) {
+			filename, _ := utils.SplitFilename(qm.filename)
+
+			filenameSnake, err := casing.SnakeCase(filename)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			genFileName := fmt.Sprintf("%s.gen.go", filenameSnake)
+
+			fullPath := path.Join(workDir, "fixtures", genFileName)
+
+			assert.Equal(t, fullPath, pen.FullPath)
+
+			got := pen.Content
+
+			cupaloy.SnapshotT(t, got)
+		})
+	}
+}
+
+func TestConfig_GenerateMysql(t *testing.T) {
+	t.Parallel()
+
+	models := []string{
+		"actors",
+		"companies",
+		"crew",
+		"hyper_parameters",
+		"movies",
+		"movies_actors",
+		"movies_companies",
+		"movies_countries",
+		"movies_crew",
+		"movies_genres",
+		"movies_languages",
+	}
+
+	queryMetas := []queryMeta{
+		{
+			name:     "list actors",
+			filename: "list-actors.sql",
+			query:    withArtifact("../introspect/mysql/fixtures/list-actors.sql"),
+			result:   withArtifact("../introspect/mysql/fixtures/list-actors.csv"),
+		},
+		{
+			name:     "get actor",
+			filename: "get-actor.sql",
+			query:    withArtifact("../introspect/mysql/fixtures/get-actor.sql"),
+			result:   withArtifact("../introspect/mysql/fixtures/get-actor.csv"),
+		},
+		{
+			name:     "list movies",
+			filename: "list-movies.sql",
+			query:    withArtifact("../introspect/mysql/fixtures/list-movies.sql"),
+			result:   withArtifact("../introspect/mysql/fixtures/list-movies.csv"),
+		},
+		{
+			name:     "get movie",
+			filename: "get-movie.sql",
+			query:    withArtifact("../introspect/pg/fixtures/get-movie.sql"),
+			result:   withArtifact("../introspect/pg/fixtures/get-movie.csv"),
+		},
+	}
+
+	db, mock, err := utils.NewMockSqlx()
+
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+
+	defer func(db *sqlx.DB) {
+		err := db.Close()
+
+		if err != nil {
+			t.Fatalf("failed to close mock db: %v", err)
+		}
+	}(db)
+
+	prepareMysqlMock(queryMetas, &mock)
+
+	tx, err := db.Beginx()
+
+	if err != nil {
+		t.Fatalf("failed to create transaction: %v", err)
+	}
+
+	defer func(tx *sqlx.Tx) {
+		err := tx.Rollback()
+
+		if err != nil {
+			t.Fatalf("failed to rollback transaction: %v", err)
+		}
+	}(tx)
+
+	fd := withFileDiscovery(queryMetas)
+
+	mw := writer.NewMemoryWriters()
+
+	wc := mw.Creator
+
+	cfg, err := withMysqlConfig()
+
+	if err != nil {
+		t.Fatalf("failed to create config: %v", err)
+	}
+
+	workDir := t.TempDir()
+
+	err = withProjectContext(workDir)
+
+	err = cfg.generateMysql(fd, wc, tx, workDir)
+
+	assert.NoError(t, err)
+
+	storePen, modelPens, queryPens := mw.Writers[0], mw.Writers[1:1+len(models)], mw.Writers[1+len(models):]
+
+	t.Run("store", func(t *testing.T) {
+		pen := storePen
+
+		fullPath := path.Join(workDir, "gen/mysql/store/store.gen.go")
+
+		assert.Equal(t, fullPath, pen.FullPath)
+
+		got := pen.Content
+
+		cupaloy.SnapshotT(t, got)
+	})
+
+	for i, pen := range modelPens {
+		modelName := inflection.Singular(models[i])
+
+		t.Run(modelName, func(t *testing.T) {
+			modelSnake, err := casing.SnakeCase(modelName)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			genFileName := fmt.Sprintf("%s.gen.go", modelSnake)
+
+			fullPath := path.Join(workDir, "gen/mysql/models", genFileName)
+
+			assert.Equal(t, fullPath, pen.FullPath)
+
+			got := pen.Content
+
+			cupaloy.SnapshotT(t, got)
+		})
+	}
+
+	for i, pen := range queryPens {
+		qm := queryMetas[i]
+
+		t.Run(qm.name, func(t *testing.T) {
+			filename, _ := utils.SplitFilename(qm.filename)
+
+			filenameSnake, err := casing.SnakeCase(filename)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			genFileName := fmt.Sprintf("%s.gen.go", filenameSnake)
+
+			fullPath := path.Join(workDir, "fixtures", genFileName)
+
+			assert.Equal(t, fullPath, pen.FullPath)
+
+			got := pen.Content
+
+			cupaloy.SnapshotT(t, got)
+		})
+	}
+}
+
+func TestConfig_GenerateSqlite(t *testing.T) {
+	t.Parallel()
+
+	models := []string{
+		"actors",
+		"movie_titles",
+		"movies",
+		"movies_actors",
+	}
+
+	queryMetas := []queryMeta{
+		{
+			name:     "list actors",
+			filename: "list-actors.sql",
+			query:    withArtifact("../introspect/sqlite/fixtures/list-actors.sql"),
+		},
+		{
+			name:     "get movie",
+			filename: "get-movie.sql",
+			query:    withArtifact("../introspect/sqlite/fixtures/get-movie.sql"),
+		},
+		{
+			name:     "named actor queries",
+			filename: "actors.sql",
+			query:    withArtifact("../introspect/sqlite/fixtures/actors.sql"),
+		},
+		{
+			name:     "delete actor",
+			filename: "delete-actor.sql",
+			query:    withArtifact("../introspect/sqlite/fixtures/delete-actor.sql"),
+		},
+	}
+
+	db, err := utils.NewTempSqlite(t.TempDir(), withArtifact("../introspect/sqlite/fixtures/schema.sql"))
+
+	if err != nil {
+		t.Fatalf("failed to create sqlite db: %v", err)
+	}
+
+	defer func(db *sqlx.DB) {
+		err := db.Close()
+
+		if err != nil {
+			t.Fatalf("failed to close sqlite db: %v", err)
+		}
+	}(db)
+
+	tx, err := db.Beginx()
+
+	if err != nil {
+		t.Fatalf("failed to create transaction: %v", err)
+	}
+
+	defer func(tx *sqlx.Tx) {
+		err := tx.Rollback()
+
+		if err != nil {
+			t.Fatalf("failed to rollback transaction: %v", err)
+		}
+	}(tx)
+
+	fd := withFileDiscovery(queryMetas)
+
+	mw := writer.NewMemoryWriters()
+
+	wc := mw.Creator
+
+	cfg, err := withSqliteConfig()
+
+	if err != nil {
+		t.Fatalf("failed to create config: %v", err)
+	}
+
+	workDir := t.TempDir()
+
+	err = withProjectContext(workDir)
+
+	err = cfg.generateSqlite(fd, wc, tx, workDir)
+
+	assert.NoError(t, err)
+
+	storePen, modelPens, queryPens := mw.Writers[0], mw.Writers[1:1+len(models)], mw.Writers[1+len(models):]
+
+	t.Run("store", func(t *testing.T) {
+		pen := storePen
+
+		fullPath := path.Join(workDir, "gen/sqlite/store/store.gen.go")
+
+		assert.Equal(t, fullPath, pen.FullPath)
+
+		got := pen.Content
+
+		cupaloy.SnapshotT(t, got)
+	})
+
+	for i, pen := range modelPens {
+		modelName := inflection.Singular(models[i])
+
+		t.Run(modelName, func(t *testing.T) {
+			modelSnake, err := casing.SnakeCase(modelName)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			genFileName := fmt.Sprintf("%s.gen.go", modelSnake)
+
+			fullPath := path.Join(workDir, "gen/sqlite/models", genFileName)
+
+			assert.Equal(t, fullPath, pen.FullPath)
+
+			got := pen.Content
+
+			cupaloy.SnapshotT(t, got)
+		})
+	}
+
+	for i, pen := range queryPens {
+		qm := queryMetas[i]
+
+		t.Run(qm.name, func(t *testing.T) {
+			filename, _ := utils.SplitFilename(qm.filename)
+
+			filenameSnake, err := casing.SnakeCase(filename)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			genFileName := fmt.Sprintf("%s.gen.go", filenameSnake)
+
+			fullPath := path.Join(workDir, "fixtures", genFileName)
+
+			assert.Equal(t, fullPath, pen.FullPath)
+
+			got := pen.Content
+
+			cupaloy.SnapshotT(t, got)
+		})
+	}
+}
+
+func TestConfig_GenerateOverrides(t *testing.T) {
+	t.Parallel()
+
+	queryMetas := []queryMeta{
+		{
+			name:     "list actors",
+			filename: "list-actors.sql",
+			query:    withArtifact("../introspect/sqlite/fixtures/list-actors.sql"),
+		},
+	}
+
+	db, err := utils.NewTempSqlite(t.TempDir(), withArtifact("../introspect/sqlite/fixtures/schema.sql"))
+
+	if err != nil {
+		t.Fatalf("failed to create sqlite db: %v", err)
+	}
+
+	defer func(db *sqlx.DB) {
+		err := db.Close()
+
+		if err != nil {
+			t.Fatalf("failed to close sqlite db: %v", err)
+		}
+	}(db)
+
+	tx, err := db.Beginx()
+
+	if err != nil {
+		t.Fatalf("failed to create transaction: %v", err)
+	}
+
+	defer func(tx *sqlx.Tx) {
+		err := tx.Rollback()
+
+		if err != nil {
+			t.Fatalf("failed to rollback transaction: %v", err)
+		}
+	}(tx)
+
+	mw := writer.NewMemoryWriters()
+
+	cfg, err := withSqliteConfig()
+
+	if err != nil {
+		t.Fatalf("failed to create config: %v", err)
+	}
+
+	cfg.Overrides = []types.Override{
+		{DbType: "numeric", GoType: "github.com/shopspring/decimal.Decimal"},
+		{DbType: "text", GoType: "database/sql.NullString"},
+		{Column: "main.movies.keywords", GoType: "encoding/json.RawMessage"},
+		{Column: "movies.title", GoType: "string"},
+	}
+
+	workDir := t.TempDir()
+
+	err = withProjectContext(workDir)
+
+	if err != nil {
+		t.Fatalf("failed to create project context: %v", err)
+	}
+
+	err = cfg.generateSqlite(withFileDiscovery(queryMetas), mw.Creator, tx, workDir)
+
+	assert.NoError(t, err)
+
+	contents := make(map[string]string)
+
+	for _, pen := range mw.Writers {
+		contents[path.Base(pen.FullPath)] = pen.Content
+	}
+
+	movie := contents["movie.gen.go"]
+
+	assert.Contains(t, movie, `"github.com/shopspring/decimal"`)
+	assert.Regexp(t, `Budget\s+\*decimal.Decimal`, movie)
+	assert.Regexp(t, `Keywords\s+\*json.RawMessage`, movie)
+	assert.Regexp(t, `Title\s+\*string`, movie)
+	assert.Regexp(t, `NameSearch\s+\*sql.NullString`, contents["actor.gen.go"])
+
+	listActors := contents["list_actors.gen.go"]
+
+	assert.Contains(t, listActors, `"database/sql"`)
+	assert.Regexp(t, `Search\s+\*sql.NullString`, listActors)
+}
+
+func TestConfig_GenerateOverrides_Invalid(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := withSqliteConfig()
+
+	if err != nil {
+		t.Fatalf("failed to create config: %v", err)
+	}
+
+	cfg.Overrides = []types.Override{
+		{DbType: "numeric", Column: "movies.budget", GoType: "github.com/shopspring/decimal.Decimal"},
+	}
+
+	err = cfg.generateSqlite(withFileDiscovery(nil), writer.NewMemoryWriters().Creator, nil, t.TempDir())
+
+	assert.ErrorContains(t, err, "needs exactly one of dbType or column")
+}
+
+func TestConfig_GenerateFieldStyle(t *testing.T) {
+	t.Parallel()
+
+	db, err := utils.NewTempSqlite(t.TempDir(), withArtifact("../introspect/sqlite/fixtures/schema.sql"))
+
+	if err != nil {
+		t.Fatalf("failed to create sqlite db: %v", err)
+	}
+
+	defer func(db *sqlx.DB) {
+		err := db.Close()
+
+		if err != nil {
+			t.Fatalf("failed to close sqlite db: %v", err)
+		}
+	}(db)
+
+	tx, err := db.Beginx()
+
+	if err != nil {
+		t.Fatalf("failed to create transaction: %v", err)
+	}
+
+	defer func(tx *sqlx.Tx) {
+		err := tx.Rollback()
+
+		if err != nil {
+			t.Fatalf("failed to rollback transaction: %v", err)
+		}
+	}(tx)
+
+	mw := writer.NewMemoryWriters()
+
+	cfg, err := withSqliteConfig()
+
+	if err != nil {
+		t.Fatalf("failed to create config: %v", err)
+	}
+
+	fieldStyle := "nullable"
+
+	cfg.Options = &types.Option{FieldStyle: &fieldStyle}
+
+	workDir := t.TempDir()
+
+	err = withProjectContext(workDir)
+
+	if err != nil {
+		t.Fatalf("failed to create project context: %v", err)
+	}
+
+	err = cfg.generateSqlite(withFileDiscovery(nil), mw.Creator, tx, workDir)
+
+	assert.NoError(t, err)
+
+	contents := make(map[string]string)
+
+	for _, pen := range mw.Writers {
+		contents[path.Base(pen.FullPath)] = pen.Content
+	}
+
+	movie := contents["movie.gen.go"]
+
+	assert.Contains(t, movie, `"database/sql"`)
+	assert.Regexp(t, `Title\s+string`, movie)
+	assert.Regexp(t, `ReleaseDate\s+sql.Null\[time.Time\]`, movie)
+	assert.Contains(t, movie, `fmt.Sprintf("ReleaseDate: %v", m.ReleaseDate)`)
+	assert.Contains(t, movie, `ReleaseDate: sql.Null[time.Time]{V: releaseDate, Valid: true}`)
+
+	invalid := "optional"
+
+	cfg.Options = &types.Option{FieldStyle: &invalid}
+
+	err = cfg.generateSqlite(withFileDiscovery(nil), mw.Creator, tx, workDir)
+
+	assert.ErrorContains(t, err, "unknown field style optional")
+}
+
+func TestConfig_GenerateTemplates(t *testing.T) {
+	t.Parallel()
+
+	queryMetas := []queryMeta{
+		{
+			name:     "list actors",
+			filename: "list-actors.sql",
+			query:    withArtifact("../introspect/sqlite/fixtures/list-actors.sql"),
+		},
+	}
+
+	db, err := utils.NewTempSqlite(t.TempDir(), withArtifact("../introspect/sqlite/fixtures/schema.sql"))
+
+	if err != nil {
+		t.Fatalf("failed to create sqlite db: %v", err)
+	}
+
+	defer func(db *sqlx.DB) {
+		err := db.Close()
+
+		if err != nil {
+			t.Fatalf("failed to close sqlite db: %v", err)
+		}
+	}(db)
+
+	tx, err := db.Beginx()
+
+	if err != nil {
+		t.Fatalf("failed to create transaction: %v", err)
+	}
+
+	defer func(tx *sqlx.Tx) {
+		err := tx.Rollback()
+
+		if err != nil {
+			t.Fatalf("failed to rollback transaction: %v", err)
+		}
+	}(tx)
+
+	workDir := t.TempDir()
+
+	err = withProjectContext(workDir)
+
+	if err != nil {
+		t.Fatalf("failed to create project context: %v", err)
+	}
+
+	templateFiles := map[string]string{
+		"templates/model.go.tmpl": "package {{ .PackageName }}\n\n" +
+			"type {{ .Model.PascalName }} struct {\n" +
+			"{{ range .Model.Fields }}\t{{ .Name }} {{ .Type.GoType }}\n{{ end }}}\n",
+		"templates/store.go.tmpl": "package {{ .PackageName }}\n\n" +
+			"const Banner = \"{{ index .Options \"sqliteModelBanner\" }}\"\n",
+		"templates/repo.go.tmpl": "package {{ .PackageName }}\n\n" +
+			"func ({{ .Model.CamelName }} *{{ .Model.PascalName }}) PkCount() int { return {{ len .Model.PkFields }} }\n",
+		"templates/queries.md.tmpl": "{{ range .Queries }}# {{ .PascalName }}\n{{ end }}",
+	}
 
-	for i, pen := range modelPens {
-		modelName := inflection.Singular(models[i])
+	for name, content := range templateFiles {
+		err := os.MkdirAll(path.Join(workDir, path.Dir(name)), 0755)
 
-		t.Run(modelName, func(t *testing.T) {
-			modelSnake, err := casing.SnakeCase(modelName)
+		if err == nil {
+			err = os.WriteFile(path.Join(workDir, name), []byte(content), 0644)
+		}
 
-			if err != nil {
-				t.Fatal(err)
-			}
+		if err != nil {
+			t.Fatalf("failed to write template %s: %v", name, err)
+		}
+	}
 
-			genFileName := fmt.Sprintf("%s.gen.go", modelSnake)
+	cfg, err := withSqliteConfig()
 
-			fullPath := path.Join(workDir, "gen/pg/models", genFileName)
+	if err != nil {
+		t.Fatalf("failed to create config: %v", err)
+	}
 
-			assert.Equal(t, fullPath, pen.FullPath)
+	banner := "custom banner"
 
-			got := pen.Content
+	cfg.Options = &types.Option{SqliteModelBanner: &banner}
 
-			cupaloy.SnapshotT(t, got)
-		})
+	cfg.Templates = &types.Templates{
+		Model: "templates/model.go.tmpl",
+		Store: "templates/store.go.tmpl",
+		Tables: []types.ExtraTemplate{
+			{Path: "templates/repo.go.tmpl", FileName: "{{ .Model.FileName }}_repo.gen.go"},
+		},
+		Queries: []types.ExtraTemplate{
+			{Path: path.Join(workDir, "templates/queries.md.tmpl"), FileName: "{{ .FileName }}.md"},
+		},
 	}
 
-	for i, pen := range queryPens {
-		qm := queryMetas[i]
+	mw := writer.NewMemoryWriters()
 
-		t.Run(qm.name, func(t *testing.T) {
-			filename, _ := utils.SplitFilename(qm.filename)
+	err = cfg.generateSqlite(withFileDiscovery(queryMetas), mw.Creator, tx, workDir)
 
-			filenameSnake, err := casing.SnakeCase(filename)
+	assert.NoError(t, err)
 
-			if err != nil {
-				t.Fatal(err)
-			}
+	contents := make(map[string]string)
 
-			genFileName := fmt.Sprintf("%s.gen.go", filenameSnake)
+	for _, pen := range mw.Writers {
+		rel := strings.TrimPrefix(pen.FullPath, workDir+"/")
 
-			fullPath := path.Join(workDir, "fixtures", genFileName)
+		contents[rel] = pen.Content
+	}
 
-			assert.Equal(t, fullPath, pen.FullPath)
+	assert.Equal(t, "package store\n\nconst Banner = \"custom banner\"\n", contents["gen/sqlite/store/store.gen.go"])
+	assert.Regexp(t, `type Actor struct \{\n\s+Id\s+\*int64`, contents["gen/sqlite/models/actor.gen.go"])
+	assert.Contains(t, contents["gen/sqlite/models/actor_repo.gen.go"], "func (actor *Actor) PkCount() int { return 1 }")
+	assert.Contains(t, contents["gen/sqlite/models/movies_actor_repo.gen.go"], "return 2")
+	assert.Equal(t, "# ListActors\n", contents["fixtures/list_actors.md"])
+	assert.Contains(t, contents["fixtures/list_actors.gen.go"], "type ListActorsArgs struct")
+}
 
-			got := pen.Content
+func TestConfig_GenerateTemplates_Missing(t *testing.T) {
+	t.Parallel()
 
-			cupaloy.SnapshotT(t, got)
-		})
+	cfg, err := withSqliteConfig()
+
+	if err != nil {
+		t.Fatalf("failed to create config: %v", err)
 	}
+
+	cfg.Templates = &types.Templates{Model: "templates/missing.go.tmpl"}
+
+	err = cfg.generateSqlite(withFileDiscovery(nil), writer.NewMemoryWriters().Creator, nil, t.TempDir())
+
+	assert.ErrorContains(t, err, "unable to read template")
 }
 
-func TestConfig_GenerateMysql(t *testing.T) {
+func TestConfig_GeneratePlugins(t *testing.T) {
 	t.Parallel()
 
-	models := []string{
-		"actors",
-		"companies",
-		"crew",
-		"hyper_parameters",
-		"movies",
-		"movies_actors",
-		"movies_companies",
-		"movies_countries",
-		"movies_crew",
-		"movies_genres",
-		"movies_languages",
+	command := path.Join(t.TempDir(), "plugin")
+
+	out, err := exec.Command("go", "build", "-o", command, "../generate/plugin/testdata/plugin").CombinedOutput()
+
+	if err != nil {
+		t.Fatalf("failed to build plugin: %v: %s", err, out)
 	}
 
 	queryMetas := []queryMeta{
 		{
 			name:     "list actors",
 			filename: "list-actors.sql",
-			query:    withArtifact("../introspect/mysql/fixtures/list-actors.sql"),
-			result:   withArtifact("../introspect/mysql/fixtures/list-actors.csv"),
-		},
-		{
-			name:     "get actor",
-			filename: "get-actor.sql",
-			query:    withArtifact("../introspect/mysql/fixtures/get-actor.sql"),
-			result:   withArtifact("../introspect/mysql/fixtures/get-actor.csv"),
-		},
-		{
-			name:     "list movies",
-			filename: "list-movies.sql",
-			query:    withArtifact("../introspect/mysql/fixtures/list-movies.sql"),
-			result:   withArtifact("../introspect/mysql/fixtures/list-movies.csv"),
-		},
-		{
-			name:     "get movie",
-			filename: "get-movie.sql",
-			query:    withArtifact("../introspect/pg/fixtures/get-movie.sql"),
-			result:   withArtifact("../introspect/pg/fixtures/get-movie.csv"),
+			query:    withArtifact("../introspect/sqlite/fixtures/list-actors.sql"),
 		},
 	}
 
-	db, mock, err := utils.NewMockSqlx()
+	db, err := utils.NewTempSqlite(t.TempDir(), withArtifact("../introspect/sqlite/fixtures/schema.sql"))
 
 	if err != nil {
-		t.Fatalf("failed to create mock db: %v", err)
+		t.Fatalf("failed to create sqlite db: %v", err)
 	}
 
 	defer func(db *sqlx.DB) {
 		err := db.Close()
 
 		if err != nil {
-			t.Fatalf("failed to close mock db: %v", err)
+			t.Fatalf("failed to close sqlite db: %v", err)
 		}
 	}(db)
 
-	prepareMysqlMock(queryMetas, &mock)
-
 	tx, err := db.Beginx()
 
 	if err != nil {
@@ -360,83 +962,180 @@ func TestConfig_GenerateMysql(t *testing.T) {
 		}
 	}(tx)
 
-	fd := withFileDiscovery(queryMetas)
+	workDir := t.TempDir()
 
-	mw := writer.NewMemoryWriters()
+	err = withProjectContext(workDir)
 
-	wc := mw.Creator
+	if err != nil {
+		t.Fatalf("failed to create project context: %v", err)
+	}
 
-	cfg, err := withMysqlConfig()
+	cfg, err := withSqliteConfig()
 
 	if err != nil {
 		t.Fatalf("failed to create config: %v", err)
 	}
 
+	cfg.Overrides = []types.Override{{Column: "actors.name", GoType: "example.com/names.Name"}}
+
+	cfg.Plugins = []types.Plugin{
+		{Name: "list", Command: command, Options: map[string]string{"dir": "gen/list"}},
+	}
+
+	mw := writer.NewMemoryWriters()
+
+	err = cfg.generateSqlite(withFileDiscovery(queryMetas), mw.Creator, tx, workDir)
+
+	assert.NoError(t, err)
+
+	contents := make(map[string]string)
+
+	for _, pen := range mw.Writers {
+		contents[strings.TrimPrefix(pen.FullPath, workDir+"/")] = pen.Content
+	}
+
+	assert.Equal(t, "v1 Id *int64\nv1 Name *names.Name\nv1 NameSearch *string\n", contents["gen/list/actors.txt"])
+	assert.Contains(t, contents["gen/list/list-actors.sql.txt"], "v1 Search *string\n")
+	assert.Contains(t, contents, "gen/sqlite/models/actor.gen.go")
+}
+
+func TestConfig_Inspect(t *testing.T) {
+	t.Parallel()
+
+	queryMetas := []queryMeta{
+		{
+			name:     "list actors",
+			filename: "list-actors.sql",
+			query:    withArtifact("../introspect/sqlite/fixtures/list-actors.sql"),
+		},
+	}
+
+	connect := func(engine string, _ string) (*sqlx.DB, error) {
+		assert.Equal(t, "sqlite", engine)
+
+		return utils.NewTempSqlite(t.TempDir(), withArtifact("../introspect/sqlite/fixtures/schema.sql"))
+	}
+
 	workDir := t.TempDir()
 
-	err = withProjectContext(workDir)
+	err := withProjectContext(workDir)
 
-	err = cfg.generateMysql(fd, wc, tx, workDir)
+	if err != nil {
+		t.Fatalf("failed to create project context: %v", err)
+	}
+
+	cfg, err := withSqliteConfig()
+
+	if err != nil {
+		t.Fatalf("failed to create config: %v", err)
+	}
+
+	cfg.Overrides = []types.Override{{DbType: "numeric", GoType: "github.com/shopspring/decimal.Decimal"}}
+
+	gen := &SqlxGen{ProjectDir: &workDir, Connect: connect, Fd: withFileDiscovery(queryMetas), Configs: []Config{*cfg}}
+
+	got, err := gen.Inspect("")
 
 	assert.NoError(t, err)
 
-	storePen, modelPens, queryPens := mw.Writers[0], mw.Writers[1:1+len(models)], mw.Writers[1+len(models):]
+	assert.Len(t, got, 1)
 
-	t.Run("store", func(t *testing.T) {
-		pen := storePen
+	request := got[0]
 
-		fullPath := path.Join(workDir, "gen/mysql/store/store.gen.go")
+	assert.Equal(t, "1", request.Version)
+	assert.Equal(t, "sqlite", request.Name)
+	assert.Equal(t, "sqlite", request.Engine)
+	assert.Equal(t, "github.com/mvoorberg/sqlxgen/gen/sqlite/store", request.StorePackageDir)
 
-		assert.Equal(t, fullPath, pen.FullPath)
+	tableNames := array.Map(request.Tables, func(table plugin.Table, _ int) string { return table.TableName })
 
-		got := pen.Content
+	assert.Equal(t, []string{"actors", "movie_titles", "movies", "movies_actors"}, tableNames)
 
-		cupaloy.SnapshotT(t, got)
-	})
+	goTypes := func(fields []plugin.Field) map[string]string {
+		goTypes := make(map[string]string)
 
-	for i, pen := range modelPens {
-		modelName := inflection.Singular(models[i])
+		for _, f := range fields {
+			goTypes[f.Name] = f.GoType
+		}
 
-		t.Run(modelName, func(t *testing.T) {
-			modelSnake, err := casing.SnakeCase(modelName)
+		return goTypes
+	}
 
-			if err != nil {
-				t.Fatal(err)
-			}
+	assert.Equal(t, "*decimal.Decimal", goTypes(request.Tables[2].Fields)["Budget"])
 
-			genFileName := fmt.Sprintf("%s.gen.go", modelSnake)
+	assert.Len(t, request.Queries, 1)
+	assert.Equal(t, "*string", goTypes(request.Queries[0].Params)["Search"])
+	assert.Equal(t, "interface{}", goTypes(request.Queries[0].Fields)["TotalRecordsCount"])
 
-			fullPath := path.Join(workDir, "gen/mysql/models", genFileName)
+	_, err = gen.Inspect("missing")
 
-			assert.Equal(t, fullPath, pen.FullPath)
+	assert.ErrorContains(t, err, "no config named missing")
+}
 
-			got := pen.Content
+func TestConfig_GenerateDdl(t *testing.T) {
+	t.Parallel()
 
-			cupaloy.SnapshotT(t, got)
-		})
+	ddlFiles := []string{"00001_create_movies.sql", "00002_alter_movies.sql"}
+
+	fd := fs.NewFakeFileDiscovery(
+		array.Map(
+			ddlFiles,
+			func(filename string, _ int) fs.FakeDiscover {
+				return fs.FakeDiscover{
+					Content:  withArtifact(path.Join("../introspect/ddl/fixtures/pg", filename)),
+					Dir:      "migrations",
+					Filename: filename,
+					FullPath: path.Join("migrations", filename),
+				}
+			},
+		),
+	)
+
+	connect := func(_ string, _ string) (*sqlx.DB, error) {
+		t.Fatal("no database connection expected when reading ddl files")
+
+		return nil, nil
 	}
 
-	for i, pen := range queryPens {
-		qm := queryMetas[i]
+	mw := writer.NewMemoryWriters()
 
-		t.Run(qm.name, func(t *testing.T) {
-			filename, _ := utils.SplitFilename(qm.filename)
+	cfg, err := withPgConfig()
 
-			filenameSnake, err := casing.SnakeCase(filename)
+	if err != nil {
+		t.Fatalf("failed to create config: %v", err)
+	}
 
-			if err != nil {
-				t.Fatal(err)
-			}
+	cfg.Source.Models.Ddl = []string{"migrations"}
 
-			genFileName := fmt.Sprintf("%s.gen.go", filenameSnake)
+	cfg.Source.Models.Exclude = []string{}
 
-			fullPath := path.Join(workDir, "fixtures", genFileName)
+	cfg.Source.Queries.Paths = []string{}
 
-			assert.Equal(t, fullPath, pen.FullPath)
+	workDir := t.TempDir()
 
-			got := pen.Content
+	err = withProjectContext(workDir)
 
-			cupaloy.SnapshotT(t, got)
+	err = cfg.Generate(connect, fd, mw.Creator, workDir)
+
+	assert.NoError(t, err)
+
+	wantFiles := []string{
+		"gen/pg/store/store.gen.go",
+		"gen/pg/store/enums.gen.go",
+		"gen/pg/store/copy.gen.go",
+		"gen/pg/models/actor.gen.go",
+		"gen/pg/models/company.gen.go",
+		"gen/pg/models/movie.gen.go",
+		"gen/pg/models/movies_actor.gen.go",
+	}
+
+	assert.Equal(t, len(wantFiles), len(mw.Writers))
+
+	for idx, pen := range mw.Writers {
+		t.Run(strings.TrimSuffix(path.Base(pen.FullPath), ".gen.go"), func(t *testing.T) {
+			assert.Equal(t, path.Join(workDir, wantFiles[idx]), pen.FullPath)
+
+			cupaloy.SnapshotT(t, pen.Content)
 		})
 	}
 }
@@ -520,7 +1219,9 @@ func TestConfig_Generate(t *testing.T) {
 
 		assert.NoError(t, err)
 
-		storePen, modelPens, queryPens := mw.Writers[0], mw.Writers[1:1+len(models)], mw.Writers[1+len(models):]
+		storePen, copyPen := mw.Writers[0], mw.Writers[1]
+
+		modelPens, queryPens := mw.Writers[2:2+len(models)], mw.Writers[2+len(models):]
 
 		t.Run("store", func(t *testing.T) {
 			pen := storePen
@@ -534,6 +1235,18 @@ func TestConfig_Generate(t *testing.T) {
 			cupaloy.SnapshotT(t, got)
 		})
 
+		t.Run("copy", func(t *testing.T) {
+			pen := copyPen
+
+			fullPath := path.Join(workDir, "gen/pg/store/copy.gen.go")
+
+			assert.Equal(t, fullPath, pen.FullPath)
+
+			got := pen.Content
+
+			cupaloy.SnapshotT(t, got)
+		})
+
 		for i, pen := range modelPens {
 			modelName := inflection.Singular(models[i])
 
@@ -813,6 +1526,42 @@ gen:
 	return cfg, nil
 }
 
+func withSqliteConfig() (*Config, error) {
+	// language=yaml
+	sqliteCfgContent := `
+name: sqlite
+engine: sqlite # postgres, mysql, sqlite
+database:
+  db: "sqlite.db"
+source:
+  models:
+    schemas:
+      - main
+    include: []
+    exclude: []
+  queries:
+    paths:
+      - gen/sqlite
+    include: []
+    exclude: []
+gen:
+  store:
+    path: gen/sqlite/store
+  models:
+    path: gen/sqlite/models
+`
+
+	cfg := &Config{}
+
+	err := yaml.Unmarshal([]byte(sqliteCfgContent), cfg)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
 func preparePgMock(
 	qms []queryMeta,
 	mock *sqlmock.Sqlmock,
@@ -825,13 +1574,40 @@ func preparePgMock(
 		ExpectQuery("select (.+) from pg_catalog.pg_attribute attr (.+) where").
 		WithArgs("public").
 		WillReturnRows(
-			sqlmock.NewRows([]string{"schema_name", "table_name", "columns"}).
+			sqlmock.NewRows([]string{"schema_name", "table_name", "columns", "foreign_keys", "unique_keys"}).
 				FromCSVString(
 					withArtifact("../introspect/pg/fixtures/tables.csv"),
 				),
 		)
 
 	for _, qm := range qms {
+		if params, _ := introspect.ParseParams(qm.query); len(params) > 0 {
+			m.ExpectExec("^savepoint sqlxgen_params").
+				WillReturnResult(
+					sqlmock.NewResult(0, 0),
+				)
+
+			m.ExpectExec("^prepare sqlxgen_params as (.+)").
+				WillReturnResult(
+					sqlmock.NewResult(0, 0),
+				)
+
+			m.ExpectQuery("select (.+) from pg_prepared_statements ps (.+)").
+				WillReturnRows(
+					sqlmock.NewRows([]string{"position", "type", "type_id", "is_array"}),
+				)
+
+			m.ExpectExec("^release savepoint sqlxgen_params").
+				WillReturnResult(
+					sqlmock.NewResult(0, 0),
+				)
+
+			m.ExpectExec("^deallocate sqlxgen_params").
+				WillReturnResult(
+					sqlmock.NewResult(0, 0),
+				)
+		}
+
 		m.ExpectExec("drop table if exists sample_query_introspection").
 			WillReturnResult(
 				sqlmock.NewResult(0, 0),
@@ -847,6 +1623,32 @@ func preparePgMock(
 				sqlmock.NewRows([]string{"column_name", "type", "type_id", "is_array", "is_sequence", "nullable", "generated"}).
 					FromCSVString(qm.result),
 			)
+
+		m.ExpectExec("^savepoint sqlxgen_nullability").
+			WillReturnResult(
+				sqlmock.NewResult(0, 0),
+			)
+
+		m.ExpectExec("^create temp view sample_query_introspection_view as (.+)").
+			WillReturnResult(
+				sqlmock.NewResult(0, 0),
+			)
+
+		m.ExpectQuery("select (.+) from pg_rewrite r (.+)").
+			WillReturnRows(
+				sqlmock.NewRows([]string{"ev_action"}).
+					AddRow("({QUERY :rtable <> :jointree <> :targetList <>})"),
+			)
+
+		m.ExpectExec("^rollback to savepoint sqlxgen_nullability").
+			WillReturnResult(
+				sqlmock.NewResult(0, 0),
+			)
+
+		m.ExpectExec("^release savepoint sqlxgen_nullability").
+			WillReturnResult(
+				sqlmock.NewResult(0, 0),
+			)
 	}
 
 	m.ExpectRollback()
@@ -866,7 +1668,7 @@ func prepareMysqlMock(
 		ExpectQuery("select (.+) from information_schema.columns c (.+) where").
 		WithArgs("public").
 		WillReturnRows(
-			sqlmock.NewRows([]string{"schema_name", "table_name", "columns"}).
+			sqlmock.NewRows([]string{"schema_name", "table_name", "columns", "foreign_keys", "unique_keys"}).
 				FromCSVString(
 					withArtifact("../introspect/mysql/fixtures/tables.csv"),
 				),
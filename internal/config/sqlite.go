@@ -0,0 +1,47 @@
+package config
+
+import (
+	"github.com/mvoorberg/sqlxgen/internal/config/types"
+	"github.com/mvoorberg/sqlxgen/internal/utils"
+)
+
+func defaultSqliteConfig() *Config {
+	cfg := &Config{
+		Name:   utils.PointerTo("default"),
+		Engine: utils.PointerTo("sqlite"),
+		Database: &types.Database{
+			Host:     utils.PointerTo(""),
+			Port:     utils.PointerTo(""),
+			Db:       utils.PointerTo("sqlite.db"),
+			User:     utils.PointerTo(""),
+			Password: utils.PointerTo(""),
+			SslMode:  utils.PointerTo(""),
+			Url:      utils.PointerTo(""),
+		},
+		Source: &types.Source{
+			Models: &types.Model{
+				Schemas: []string{"main"},
+				Include: []string{"^.+$"},
+				Exclude: []string{},
+			},
+			Queries: &types.Query{
+				Paths:   []string{},
+				Include: []string{"^.+$"},
+				Exclude: []string{},
+			},
+		},
+		Options: &types.Option{
+			SqliteModelBanner: utils.PointerTo("This is a SQLite generated model. DO NOT EDIT."),
+		},
+		Gen: &types.Gen{
+			Store: &types.GenPartial{
+				Path: "gen/store",
+			},
+			Model: &types.GenPartial{
+				Path: "gen/sqlite",
+			},
+		},
+	}
+
+	return cfg
+}
@@ -3,8 +3,13 @@ package config
 import (
 	"fmt"
 	"log/slog"
+	"path"
+	"slices"
 
 	"github.com/joomcode/errorx"
+	"github.com/mvoorberg/sqlxgen/internal/config/types"
+	"github.com/mvoorberg/sqlxgen/internal/generate/plugin"
+	"github.com/mvoorberg/sqlxgen/internal/introspect/snapshot"
 	"github.com/mvoorberg/sqlxgen/internal/logger"
 	"github.com/mvoorberg/sqlxgen/internal/utils/fs"
 	"github.com/mvoorberg/sqlxgen/internal/utils/writer"
@@ -19,6 +24,12 @@ type SqlxGen struct {
 	ProjectDir    *string          `json:"projectDir" yaml:"projectDir"`
 	LogArgs       *logger.Args     `json:"log" yaml:"log"`
 	Configs       []Config         `json:"configs" yaml:"configs"`
+	// Overrides of the inferred Go types, for every config
+	Overrides []types.Override `json:"overrides,omitempty" yaml:"overrides"`
+	// Offline generates from the snapshot in the lock file
+	Offline bool `json:"-" yaml:"-"`
+	// SaveSnapshot writes the introspection of every config to the lock file
+	SaveSnapshot bool `json:"-" yaml:"-"`
 }
 
 func (gen *SqlxGen) InitLogger() {
@@ -38,6 +49,24 @@ func (gen *SqlxGen) Generate() error {
 		return errorx.IllegalArgument.New("unsupported sqlxgen version: %s", version)
 	}
 
+	if gen.Offline && gen.SaveSnapshot {
+		return errorx.IllegalArgument.New("a snapshot can't be saved offline")
+	}
+
+	lockPath := path.Join(*gen.ProjectDir, snapshot.FileName)
+
+	lock := snapshot.NewLock()
+
+	if gen.Offline {
+		var err error
+
+		lock, err = snapshot.Load(lockPath)
+
+		if err != nil {
+			return err
+		}
+	}
+
 	slog.Debug("starting all config generations")
 
 	slog.Info(fmt.Sprintf("have %d configs to generate", len(gen.Configs)))
@@ -47,20 +76,76 @@ func (gen *SqlxGen) Generate() error {
 
 		slog.Debug("config", "config", &cfg)
 
+		if gen.Offline {
+			sc, err := lock.Config(*cfg.Name)
+
+			if err != nil {
+				return err
+			}
+
+			cfg.snapshot = &sc
+		}
+
+		if gen.SaveSnapshot {
+			cfg.recording = &snapshot.Config{Name: *cfg.Name}
+		}
+
 		err := cfg.Generate(gen.Connect, gen.Fd, gen.WriterCreator, *gen.ProjectDir)
 
 		if err != nil {
 			return err
 		}
 
+		if gen.SaveSnapshot {
+			lock.Configs = append(lock.Configs, *cfg.recording)
+		}
+
 		slog.Info("generation ended", "name", *cfg.Name, "engine", *cfg.Engine)
 	}
 
 	slog.Debug("ended all config generations")
 
+	if gen.SaveSnapshot {
+		err := lock.Save(lockPath)
+
+		if err != nil {
+			return err
+		}
+
+		slog.Info("saved snapshot", "path", lockPath)
+	}
+
 	return nil
 }
 
+// Inspect introspects every config, or the config with the given name, and
+// returns what the plugins of the configs get.
+func (gen *SqlxGen) Inspect(name string) ([]plugin.Request, error) {
+	requests := make([]plugin.Request, 0)
+
+	for _, cfg := range gen.Configs {
+		if name != "" && *cfg.Name != name {
+			continue
+		}
+
+		slog.Info("inspecting", "name", *cfg.Name, "engine", *cfg.Engine)
+
+		request, err := cfg.Inspect(gen.Connect, gen.Fd, *gen.ProjectDir)
+
+		if err != nil {
+			return nil, err
+		}
+
+		requests = append(requests, request)
+	}
+
+	if name != "" && len(requests) == 0 {
+		return nil, errorx.IllegalArgument.New("no config named %s", name)
+	}
+
+	return requests, nil
+}
+
 func NewSqlxGen(args SqlxGenArgs) (*SqlxGen, error) {
 	content, err := loadAndExpand(args.WorkingDir, args.SqlxAltPath)
 
@@ -96,11 +181,16 @@ func withDefaults(args SqlxGenArgs, sqlxGen *SqlxGen) (*SqlxGen, error) {
 		ProjectDir:    sqlxGen.ProjectDir,
 		LogArgs:       logArgs.Merge(sqlxGen.LogArgs),
 		Configs:       make([]Config, 0),
+		Offline:       args.Offline,
+		SaveSnapshot:  args.SaveSnapshot,
 	}
 
 	for _, cfg := range sqlxGen.Configs {
 		engine := *cfg.Engine
 
+		// the overrides of a config come after, and win over, the global ones
+		cfg.Overrides = append(slices.Clone(sqlxGen.Overrides), cfg.Overrides...)
+
 		if engine == "postgres" {
 			pgConfig := defaultPgConfig()
 
@@ -121,6 +211,16 @@ func withDefaults(args SqlxGenArgs, sqlxGen *SqlxGen) (*SqlxGen, error) {
 			continue
 		}
 
+		if engine == "sqlite" {
+			sqliteConfig := defaultSqliteConfig()
+
+			safeCfg := sqliteConfig.Merge(&cfg)
+
+			safeGenCfg.Configs = append(safeGenCfg.Configs, *safeCfg)
+
+			continue
+		}
+
 		return nil, errorx.IllegalArgument.New("unsupported engine: %s", engine)
 	}
 
@@ -133,4 +233,6 @@ type SqlxGenArgs struct {
 	WriterCreator writer.Creator
 	WorkingDir    string
 	SqlxAltPath   string
+	Offline       bool
+	SaveSnapshot  bool
 }
@@ -326,9 +326,11 @@ func TestSqlxGen_Generate(t *testing.T) {
 
 	pgStorePen := mw.Writers[0]
 
-	pgModelPens := mw.Writers[1 : 1+len(pgModels)]
+	pgCopyPen := mw.Writers[1]
 
-	pgQueryPens := mw.Writers[1+len(pgModels) : 1+len(pgModels)+len(pgQueryMetas)]
+	pgModelPens := mw.Writers[2 : 2+len(pgModels)]
+
+	pgQueryPens := mw.Writers[2+len(pgModels) : 2+len(pgModels)+len(pgQueryMetas)]
 
 	t.Run("pg-store", func(t *testing.T) {
 		pen := pgStorePen
@@ -342,6 +344,18 @@ func TestSqlxGen_Generate(t *testing.T) {
 		cupaloy.SnapshotT(t, got)
 	})
 
+	t.Run("pg-copy", func(t *testing.T) {
+		pen := pgCopyPen
+
+		fullPath := path.Join(workDir, "gen/pg/store/copy.gen.go")
+
+		assert.Equal(t, fullPath, pen.FullPath)
+
+		got := pen.Content
+
+		cupaloy.SnapshotT(t, got)
+	})
+
 	for i, pen := range pgModelPens {
 		modelName := inflection.Singular(pgModels[i])
 
@@ -392,7 +406,7 @@ func TestSqlxGen_Generate(t *testing.T) {
 		})
 	}
 
-	mysqlOffset := 1 + len(pgModels) + len(pgQueryMetas)
+	mysqlOffset := 2 + len(pgModels) + len(pgQueryMetas)
 
 	mysqlStorePen := mw.Writers[mysqlOffset]
 
@@ -550,3 +564,91 @@ func (f ffdRouter) Find(dir string, pattern string, shallow bool) ([]fs.Discover
 
 	return fd.Find(dir, pattern, shallow)
 }
+
+func TestSqlxGen_GenerateOffline(t *testing.T) {
+	t.Parallel()
+
+	queryMetas := []queryMeta{
+		{
+			name:     "list actors",
+			filename: "list-actors.sql",
+			query:    withArtifact("../introspect/sqlite/fixtures/list-actors.sql"),
+		},
+	}
+
+	workDir := t.TempDir()
+
+	err := withProjectContext(workDir)
+
+	if err != nil {
+		t.Fatalf("failed to create project context: %v", err)
+	}
+
+	cfg, err := withSqliteConfig()
+
+	if err != nil {
+		t.Fatalf("failed to create config: %v", err)
+	}
+
+	version := "1"
+
+	connect := func(_ string, _ string) (*sqlx.DB, error) {
+		return utils.NewTempSqlite(t.TempDir(), withArtifact("../introspect/sqlite/fixtures/schema.sql"))
+	}
+
+	live := writer.NewMemoryWriters()
+
+	gen := &SqlxGen{
+		Connect:       connect,
+		Fd:            withFileDiscovery(queryMetas),
+		WriterCreator: live.Creator,
+		Version:       &version,
+		ProjectDir:    &workDir,
+		Configs:       []Config{*cfg},
+		SaveSnapshot:  true,
+	}
+
+	err = gen.Generate()
+
+	assert.NoError(t, err)
+
+	assert.FileExists(t, path.Join(workDir, "sqlxgen.lock.json"))
+
+	offline := writer.NewMemoryWriters()
+
+	gen.Connect = nil
+
+	gen.WriterCreator = offline.Creator
+
+	gen.SaveSnapshot = false
+
+	gen.Offline = true
+
+	err = gen.Generate()
+
+	assert.NoError(t, err)
+
+	contents := func(mw *writer.MemoryWriters) map[string]string {
+		contents := make(map[string]string)
+
+		for _, pen := range mw.Writers {
+			contents[pen.FullPath] = pen.Content
+		}
+
+		return contents
+	}
+
+	assert.Len(t, offline.Writers, 6)
+
+	assert.Equal(t, contents(live), contents(offline))
+
+	queryMetas[0].query += "\n-- changed"
+
+	gen.Fd = withFileDiscovery(queryMetas)
+
+	err = gen.Generate()
+
+	assert.ErrorContains(t, err, "query files are new or changed since the snapshot")
+
+	assert.ErrorContains(t, err, "fixtures/list-actors.sql")
+}
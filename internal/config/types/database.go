@@ -44,6 +44,10 @@ func (d *Database) GetUrl(engine string) (string, error) {
 		return getPgUrl(d), nil
 	}
 
+	if engine == "sqlite" {
+		return getSqliteUrl(d), nil
+	}
+
 	return "", errorx.IllegalArgument.New("invalid engine: %s", engine)
 }
 
@@ -162,6 +166,20 @@ func getMysqlUrl(d *Database) string {
 	return safeUrl
 }
 
+// getSqliteUrl opens the database file read-write, so that a wrong path
+// fails instead of introspecting a freshly created empty database.
+func getSqliteUrl(d *Database) string {
+	isUrlEmpty := d.Url == nil || *d.Url == ""
+
+	if !isUrlEmpty {
+		return *d.Url
+	}
+
+	db := safeValue("sqlite.db", d.Db)
+
+	return fmt.Sprintf("file:%s?mode=rw", db)
+}
+
 func safeValue(left string, right *string) string {
 	if right != nil && *right != "" {
 		return *right
@@ -9,6 +9,9 @@ type Model struct {
 	Schemas []string `json:"schemas" yaml:"schemas"`
 	Include []string `json:"include" yaml:"include"`
 	Exclude []string `json:"exclude" yaml:"exclude"`
+	// Ddl lists the files or directories of ddl statements (e.g. migrations)
+	// to read the tables from instead of a live database
+	Ddl []string `json:"ddl,omitempty" yaml:"ddl"`
 }
 
 func (m *Model) String() string {
@@ -16,14 +19,17 @@ func (m *Model) String() string {
 		return "Model{nil}"
 	}
 
-	content := strings.Join(
-		[]string{
-			fmt.Sprintf("schemas: %v", m.Schemas),
-			fmt.Sprintf("include: %v", m.Include),
-			fmt.Sprintf("exclude: %v", m.Exclude),
-		},
-		", ",
-	)
+	fields := []string{
+		fmt.Sprintf("schemas: %v", m.Schemas),
+		fmt.Sprintf("include: %v", m.Include),
+		fmt.Sprintf("exclude: %v", m.Exclude),
+	}
+
+	if len(m.Ddl) > 0 {
+		fields = append(fields, fmt.Sprintf("ddl: %v", m.Ddl))
+	}
+
+	content := strings.Join(fields, ", ")
 
 	return fmt.Sprintf("Model{%s}", content)
 }
@@ -49,5 +55,14 @@ func (m *Model) Merge(other *Model) *Model {
 		m.Exclude = other.Exclude
 	}
 
+	if other.Ddl != nil {
+		m.Ddl = other.Ddl
+	}
+
 	return m
 }
+
+// HasDdl reports whether the tables are read from ddl files.
+func (m *Model) HasDdl() bool {
+	return m != nil && len(m.Ddl) > 0
+}
@@ -115,6 +115,25 @@ func TestModel_Merge(t *testing.T) {
 				Exclude: []string{"other"},
 			},
 		},
+		{
+			name: "only ddl",
+			fields: fields{
+				Schemas: []string{"schema"},
+				Include: []string{"include"},
+				Exclude: []string{"exclude"},
+			},
+			args: args{
+				other: &Model{
+					Ddl: []string{"migrations"},
+				},
+			},
+			want: &Model{
+				Schemas: []string{"schema"},
+				Include: []string{"include"},
+				Exclude: []string{"exclude"},
+				Ddl:     []string{"migrations"},
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -137,6 +156,7 @@ func TestModel_String(t *testing.T) {
 		Schemas []string
 		Include []string
 		Exclude []string
+		Ddl     []string
 	}
 
 	tests := []struct {
@@ -171,6 +191,16 @@ func TestModel_String(t *testing.T) {
 			},
 			want: "Model{schemas: [schema], include: [include], exclude: [exclude]}",
 		},
+		{
+			name: "with ddl",
+			fields: fields{
+				Schemas: []string{"schema"},
+				Include: []string{"include"},
+				Exclude: []string{"exclude"},
+				Ddl:     []string{"migrations"},
+			},
+			want: "Model{schemas: [schema], include: [include], exclude: [exclude], ddl: [migrations]}",
+		},
 	}
 
 	for _, tt := range tests {
@@ -179,6 +209,7 @@ func TestModel_String(t *testing.T) {
 				Schemas: tt.fields.Schemas,
 				Include: tt.fields.Include,
 				Exclude: tt.fields.Exclude,
+				Ddl:     tt.fields.Ddl,
 			}
 
 			assert.Equal(t, tt.want, m.String())
@@ -8,9 +8,12 @@ import (
 type Option struct {
 	MysqlModelBanner        *string `json:"mysqlModelBanner" yaml:"mysqlModelBanner"`
 	PostgresModelBanner     *string `json:"postgresModelBanner" yaml:"postgresModelBanner"`
+	SqliteModelBanner       *string `json:"sqliteModelBanner" yaml:"sqliteModelBanner"`
 	PostgresInt64JsonString *bool   `json:"postgresInt64JsonString,string" yaml:"postgresInt64JsonString"`
 	CreatedDateFields       *string `json:"createdDateFields" yaml:"createdDateFields"`
 	UpdatedDateFields       *string `json:"updatedDateFields" yaml:"updatedDateFields"`
+	FieldStyle              *string `json:"fieldStyle" yaml:"fieldStyle"`
+	BulkInsertMaxParameters *int    `json:"bulkInsertMaxParameters,string" yaml:"bulkInsertMaxParameters"`
 }
 
 func (q *Option) String() string {
@@ -22,9 +25,12 @@ func (q *Option) String() string {
 		[]string{
 			fmt.Sprintf("mysqlModelBanner: %v", q.MysqlModelBanner),
 			fmt.Sprintf("postgresModelBanner: %v", q.PostgresModelBanner),
+			fmt.Sprintf("sqliteModelBanner: %v", q.SqliteModelBanner),
 			fmt.Sprintf("postgresInt64JsonString: %v", q.PostgresInt64JsonString),
 			fmt.Sprintf("createdDateFields: %v", q.CreatedDateFields),
 			fmt.Sprintf("updatedDateFields: %v", q.UpdatedDateFields),
+			fmt.Sprintf("fieldStyle: %v", q.FieldStyle),
+			fmt.Sprintf("bulkInsertMaxParameters: %v", q.BulkInsertMaxParameters),
 		},
 		", ",
 	)
@@ -51,6 +57,9 @@ func (q *Option) Merge(other *Option) *Option {
 	if other.PostgresModelBanner != nil {
 		q.PostgresModelBanner = other.PostgresModelBanner
 	}
+	if other.SqliteModelBanner != nil {
+		q.SqliteModelBanner = other.SqliteModelBanner
+	}
 
 	if other.CreatedDateFields != nil {
 		q.CreatedDateFields = other.CreatedDateFields
@@ -59,5 +68,13 @@ func (q *Option) Merge(other *Option) *Option {
 		q.UpdatedDateFields = other.UpdatedDateFields
 	}
 
+	if other.FieldStyle != nil {
+		q.FieldStyle = other.FieldStyle
+	}
+
+	if other.BulkInsertMaxParameters != nil {
+		q.BulkInsertMaxParameters = other.BulkInsertMaxParameters
+	}
+
 	return q
 }
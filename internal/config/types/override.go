@@ -0,0 +1,31 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Override maps a database type, or a single column, to a Go type given by
+// its import path and name, e.g. github.com/shopspring/decimal.Decimal.
+type Override struct {
+	DbType string `json:"dbType,omitempty" yaml:"dbType"`
+	// Column is schema.table.column, or table.column for any schema
+	Column string `json:"column,omitempty" yaml:"column"`
+	GoType string `json:"goType" yaml:"goType"`
+	// Json decodes the JSON of the column into GoType
+	Json bool `json:"json,omitempty" yaml:"json"`
+}
+
+func (o *Override) String() string {
+	content := strings.Join(
+		[]string{
+			fmt.Sprintf("dbType: %v", o.DbType),
+			fmt.Sprintf("column: %v", o.Column),
+			fmt.Sprintf("goType: %v", o.GoType),
+			fmt.Sprintf("json: %v", o.Json),
+		},
+		", ",
+	)
+
+	return fmt.Sprintf("Override{%s}", content)
+}
@@ -0,0 +1,31 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Plugin is an executable generating files from the introspected tables and
+// queries, see the plugin package for the protocol.
+type Plugin struct {
+	Name string `json:"name" yaml:"name"`
+	// Command is looked up in PATH, or relative to the project directory
+	// when it has a path
+	Command string            `json:"command" yaml:"command"`
+	Args    []string          `json:"args,omitempty" yaml:"args"`
+	Options map[string]string `json:"options,omitempty" yaml:"options"`
+}
+
+func (p Plugin) String() string {
+	content := strings.Join(
+		[]string{
+			fmt.Sprintf("name: %v", p.Name),
+			fmt.Sprintf("command: %v", p.Command),
+			fmt.Sprintf("args: %v", p.Args),
+			fmt.Sprintf("options: %v", p.Options),
+		},
+		", ",
+	)
+
+	return fmt.Sprintf("Plugin{%s}", content)
+}
@@ -0,0 +1,80 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Templates replace the built-in templates with template files, paths are
+// relative to the project directory.
+type Templates struct {
+	Model string `json:"model,omitempty" yaml:"model"`
+	Query string `json:"query,omitempty" yaml:"query"`
+	Store string `json:"store,omitempty" yaml:"store"`
+	// Tables are rendered once per table, next to the models
+	Tables []ExtraTemplate `json:"tables,omitempty" yaml:"tables"`
+	// Queries are rendered once per query file, next to the query file
+	Queries []ExtraTemplate `json:"queries,omitempty" yaml:"queries"`
+}
+
+func (t *Templates) String() string {
+	if t == nil {
+		return "Templates{nil}"
+	}
+
+	content := strings.Join(
+		[]string{
+			fmt.Sprintf("model: %v", t.Model),
+			fmt.Sprintf("query: %v", t.Query),
+			fmt.Sprintf("store: %v", t.Store),
+			fmt.Sprintf("tables: %v", t.Tables),
+			fmt.Sprintf("queries: %v", t.Queries),
+		},
+		", ",
+	)
+
+	return fmt.Sprintf("Templates{%s}", content)
+}
+
+func (t *Templates) Merge(other *Templates) *Templates {
+	if other == nil {
+		return t
+	}
+
+	if t == nil {
+		return other
+	}
+
+	if other.Model != "" {
+		t.Model = other.Model
+	}
+
+	if other.Query != "" {
+		t.Query = other.Query
+	}
+
+	if other.Store != "" {
+		t.Store = other.Store
+	}
+
+	if other.Tables != nil {
+		t.Tables = other.Tables
+	}
+
+	if other.Queries != nil {
+		t.Queries = other.Queries
+	}
+
+	return t
+}
+
+// ExtraTemplate is a template file rendered into the file named by FileName,
+// itself a template given the same data, e.g. "{{ .Model.FileName }}_repo.gen.go".
+type ExtraTemplate struct {
+	Path     string `json:"path" yaml:"path"`
+	FileName string `json:"fileName" yaml:"fileName"`
+}
+
+func (e ExtraTemplate) String() string {
+	return fmt.Sprintf("ExtraTemplate{path: %s, fileName: %s}", e.Path, e.FileName)
+}
@@ -0,0 +1,91 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplates_Merge(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		t     *Templates
+		other *Templates
+		want  *Templates
+	}{
+		{
+			name:  "nil",
+			t:     nil,
+			other: nil,
+			want:  nil,
+		},
+		{
+			name:  "nil t",
+			t:     nil,
+			other: &Templates{Model: "model.go.tmpl"},
+			want:  &Templates{Model: "model.go.tmpl"},
+		},
+		{
+			name:  "nil other",
+			t:     &Templates{Model: "model.go.tmpl"},
+			other: nil,
+			want:  &Templates{Model: "model.go.tmpl"},
+		},
+		{
+			name:  "keep",
+			t:     &Templates{Model: "model.go.tmpl", Store: "store.go.tmpl"},
+			other: &Templates{Query: "query.go.tmpl"},
+			want:  &Templates{Model: "model.go.tmpl", Query: "query.go.tmpl", Store: "store.go.tmpl"},
+		},
+		{
+			name: "overwrite",
+			t: &Templates{
+				Model:  "model.go.tmpl",
+				Tables: []ExtraTemplate{{Path: "repo.go.tmpl", FileName: "{{ .Model.FileName }}_repo.gen.go"}},
+			},
+			other: &Templates{
+				Model:   "model2.go.tmpl",
+				Tables:  []ExtraTemplate{},
+				Queries: []ExtraTemplate{{Path: "handler.go.tmpl", FileName: "{{ .FileName }}_handler.gen.go"}},
+			},
+			want: &Templates{
+				Model:   "model2.go.tmpl",
+				Tables:  []ExtraTemplate{},
+				Queries: []ExtraTemplate{{Path: "handler.go.tmpl", FileName: "{{ .FileName }}_handler.gen.go"}},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.t.Merge(testCase.other)
+
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
+func TestTemplates_String(t *testing.T) {
+	t.Parallel()
+
+	var nilTemplates *Templates
+
+	assert.Equal(t, "Templates{nil}", nilTemplates.String())
+
+	templates := &Templates{
+		Model:  "model.go.tmpl",
+		Tables: []ExtraTemplate{{Path: "repo.go.tmpl", FileName: "repo.gen.go"}},
+	}
+
+	assert.Equal(
+		t,
+		"Templates{model: model.go.tmpl, query: , store: , tables: [ExtraTemplate{path: repo.go.tmpl, fileName: repo.gen.go}], queries: []}",
+		templates.String(),
+	)
+}
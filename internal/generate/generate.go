@@ -3,9 +3,11 @@ package generate
 import (
 	"log/slog"
 	"path"
+	"path/filepath"
 
 	"github.com/joomcode/errorx"
 	"github.com/mvoorberg/sqlxgen/internal/generate/models"
+	"github.com/mvoorberg/sqlxgen/internal/generate/plugin"
 	"github.com/mvoorberg/sqlxgen/internal/generate/queries"
 	"github.com/mvoorberg/sqlxgen/internal/generate/store"
 	"github.com/mvoorberg/sqlxgen/internal/generate/types"
@@ -23,6 +25,21 @@ type Generate struct {
 	Queries         []introspect.Query
 	Translate       types.Translate
 	Options         map[string]string
+	Templates       Templates
+	// Name and Engine of the config, for the plugins
+	Name    string
+	Engine  string
+	Plugins []plugin.Plugin
+}
+
+// Templates replace the built-in templates when set, the extra templates are
+// rendered once per table and once per query file.
+type Templates struct {
+	Model   string
+	Query   string
+	Store   string
+	Tables  []types.ExtraTemplate
+	Queries []types.ExtraTemplate
 }
 
 func (gen Generate) Generate() error {
@@ -32,7 +49,17 @@ func (gen Generate) Generate() error {
 		return errorx.InitializationFailed.Wrap(err, "unable to get project package name")
 	}
 
-	storePackage, err := gen.generateStorePackage(projectPackageName)
+	tables, queries, enums, err := store.ResolveEnums(gen.Tables, gen.Queries, gen.Templates.Store)
+
+	if err != nil {
+		return errorx.IllegalState.Wrap(err, "unable to resolve enums")
+	}
+
+	gen.Tables = tables
+
+	gen.Queries = queries
+
+	storePackage, err := gen.generateStorePackage(projectPackageName, enums)
 
 	if err != nil {
 		return errorx.InternalError.Wrap(err, "unable to generate store package")
@@ -50,10 +77,16 @@ func (gen Generate) Generate() error {
 		return errorx.InternalError.Wrap(err, "unable to generate queries package")
 	}
 
+	err = gen.runPlugins(storePackage, projectPackageName)
+
+	if err != nil {
+		return errorx.InternalError.Wrap(err, "unable to run plugins")
+	}
+
 	return nil
 }
 
-func (gen Generate) generateStorePackage(projectPackageName string) (store.Package, error) {
+func (gen Generate) generateStorePackage(projectPackageName string, enums []store.Enum) (store.Package, error) {
 	storePackageDir := path.Join(projectPackageName, gen.StorePackageDir)
 
 	storeGenDir := path.Join(gen.ProjectDir, gen.StorePackageDir)
@@ -64,6 +97,12 @@ func (gen Generate) generateStorePackage(projectPackageName string) (store.Packa
 		return store.Package{}, errorx.InitializationFailed.Wrap(err, "unable to initialize store package")
 	}
 
+	storePackage.Engine = gen.Engine
+
+	storePackage.Enums = enums
+
+	storePackage.Template = gen.Templates.Store
+
 	err = storePackage.Generate()
 
 	if err != nil {
@@ -98,6 +137,12 @@ func (gen Generate) generateModelPackage(
 		return models.Package{}, errorx.InitializationFailed.Wrap(err, "unable to initialize models package")
 	}
 
+	if gen.Templates.Model != "" {
+		modelPackage.ModelTemplate = gen.Templates.Model
+	}
+
+	modelPackage.ExtraTemplates = gen.Templates.Tables
+
 	err = modelPackage.Generate()
 
 	if err != nil {
@@ -125,6 +170,14 @@ func (gen Generate) generateQueryPackage(storePackage store.Package) (queries.Pa
 		return queries.Package{}, errorx.InitializationFailed.Wrap(err, "unable to initialize queries package")
 	}
 
+	if gen.Templates.Query != "" {
+		queryPackage.QueryTemplate = gen.Templates.Query
+	}
+
+	queryPackage.Options = gen.Options
+
+	queryPackage.ExtraTemplates = gen.Templates.Queries
+
 	err = queryPackage.Generate()
 
 	if err != nil {
@@ -135,3 +188,125 @@ func (gen Generate) generateQueryPackage(storePackage store.Package) (queries.Pa
 
 	return queryPackage, nil
 }
+
+// Inspect returns the tables and queries, with the Go types of their
+// fields, the plugins get.
+func (gen Generate) Inspect() (plugin.Request, error) {
+	projectPackageName, err := utils.GetProjectPackageName(gen.ProjectDir)
+
+	if err != nil {
+		return plugin.Request{}, errorx.InitializationFailed.Wrap(err, "unable to get project package name")
+	}
+
+	tables, queries, _, err := store.ResolveEnums(gen.Tables, gen.Queries, gen.Templates.Store)
+
+	if err != nil {
+		return plugin.Request{}, errorx.IllegalState.Wrap(err, "unable to resolve enums")
+	}
+
+	gen.Tables = tables
+
+	gen.Queries = queries
+
+	storePackage, err := store.NewPackage(
+		gen.WriterCreator,
+		path.Join(projectPackageName, gen.StorePackageDir),
+		path.Join(gen.ProjectDir, gen.StorePackageDir),
+		gen.Options,
+	)
+
+	if err != nil {
+		return plugin.Request{}, errorx.InitializationFailed.Wrap(err, "unable to initialize store package")
+	}
+
+	return gen.newRequest(storePackage, projectPackageName)
+}
+
+// runPlugins writes the files of every plugin through the writer creator,
+// like the generated packages.
+func (gen Generate) runPlugins(storePackage store.Package, projectPackageName string) error {
+	if len(gen.Plugins) == 0 {
+		return nil
+	}
+
+	request, err := gen.newRequest(storePackage, projectPackageName)
+
+	if err != nil {
+		return err
+	}
+
+	for _, p := range gen.Plugins {
+		files, err := p.Run(gen.ProjectDir, request)
+
+		if err != nil {
+			return err
+		}
+
+		for _, file := range files {
+			fullPath, err := projectFilePath(gen.ProjectDir, file.Path)
+
+			if err != nil {
+				return errorx.Decorate(err, "unable to write a file of plugin %s", p.Name)
+			}
+
+			pen := gen.WriterCreator(fullPath, file.Content)
+
+			err = pen.Write()
+
+			if err != nil {
+				return errorx.InternalError.Wrap(err, "unable to write %s of plugin %s", file.Path, p.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// projectFilePath joins a relative file path to the project directory,
+// rejecting absolute paths and paths escaping the project directory.
+func projectFilePath(projectDir string, filePath string) (string, error) {
+	cleanPath := filepath.Clean(filePath)
+
+	if !filepath.IsLocal(filePath) || cleanPath == "." {
+		return "", errorx.IllegalArgument.New("%s is outside of the project directory", filePath)
+	}
+
+	return filepath.Join(projectDir, cleanPath), nil
+}
+
+func (gen Generate) newRequest(storePackage store.Package, projectPackageName string) (plugin.Request, error) {
+	request := plugin.Request{
+		Version:            plugin.Version,
+		Name:               gen.Name,
+		Engine:             gen.Engine,
+		ProjectPackageName: projectPackageName,
+		StorePackageDir:    storePackage.PackageDir,
+		StorePackageName:   storePackage.PackageName,
+		ModelPackageDir:    path.Join(projectPackageName, gen.ModelPackageDir),
+		Options:            gen.Options,
+		Tables:             make([]plugin.Table, len(gen.Tables)),
+		Queries:            make([]plugin.Query, len(gen.Queries)),
+	}
+
+	for idx, table := range gen.Tables {
+		t, err := plugin.NewTable(gen.Translate, storePackage.PackageDir, storePackage.PackageName, table)
+
+		if err != nil {
+			return plugin.Request{}, errorx.IllegalState.Wrap(err, "unable to resolve fields of table %s", table.TableName)
+		}
+
+		request.Tables[idx] = t
+	}
+
+	for idx, query := range gen.Queries {
+		q, err := plugin.NewQuery(gen.Translate, storePackage.PackageDir, storePackage.PackageName, query)
+
+		if err != nil {
+			return plugin.Request{}, errorx.IllegalState.Wrap(err, "unable to resolve fields of query %s", query.Filename)
+		}
+
+		request.Queries[idx] = q
+	}
+
+	return request, nil
+}
@@ -25,7 +25,7 @@ func TestGenerate_generateStorePackage(t *testing.T) {
 		t.Fatalf("unable to create generate object: %v", err)
 	}
 
-	_, err = gen.generateStorePackage("github.com/mvoorberg/sqlxgen-example")
+	_, err = gen.generateStorePackage("github.com/mvoorberg/sqlxgen-example", nil)
 
 	assert.Nil(t, err)
 
@@ -38,6 +38,90 @@ func TestGenerate_generateStorePackage(t *testing.T) {
 	cupaloy.SnapshotT(t, got.Content)
 }
 
+func TestGenerate_generateStorePackage_check(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cws := writer.NewCheckWriters()
+
+	gen, err := createGen(cws.Creator, nil, tmpDir)
+
+	if err != nil {
+		t.Fatalf("unable to create generate object: %v", err)
+	}
+
+	_, err = gen.generateStorePackage("github.com/mvoorberg/sqlxgen-example", nil)
+
+	assert.Nil(t, err)
+
+	assert.Equal(t, writer.CheckCreated, cws.Writers[0].Status)
+
+	_, err = os.Stat(path.Join(tmpDir, "internal/store"))
+
+	assert.True(t, os.IsNotExist(err), "check must not create the store directory")
+}
+
+func TestProjectFilePath(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		filePath string
+		want     string
+		err      string
+	}{
+		{
+			name:     "relative",
+			filePath: "gen/plugin/actors.txt",
+			want:     "/project/gen/plugin/actors.txt",
+		},
+		{
+			name:     "cleaned",
+			filePath: "gen/../gen/./actors.txt",
+			want:     "/project/gen/actors.txt",
+		},
+		{
+			name:     "parent",
+			filePath: "../../etc/passwd",
+			err:      "../../etc/passwd is outside of the project directory",
+		},
+		{
+			name:     "nested parent",
+			filePath: "gen/../../actors.txt",
+			err:      "gen/../../actors.txt is outside of the project directory",
+		},
+		{
+			name:     "absolute",
+			filePath: "/etc/passwd",
+			err:      "/etc/passwd is outside of the project directory",
+		},
+		{
+			name:     "empty",
+			filePath: "",
+			err:      "is outside of the project directory",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := projectFilePath("/project", testCase.filePath)
+
+			if testCase.err != "" {
+				assert.ErrorContains(t, err, testCase.err)
+
+				return
+			}
+
+			assert.Nil(t, err)
+
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
 func TestGenerate_generateModelPackage(t *testing.T) {
 	t.Parallel()
 
@@ -69,7 +153,7 @@ var content = `+"`"+`{
 		t.Fatalf("unable to create generate object: %v", err)
 	}
 
-	storePkg, err := gen.generateStorePackage("github.com/mvoorberg/sqlxgen-example")
+	storePkg, err := gen.generateStorePackage("github.com/mvoorberg/sqlxgen-example", nil)
 
 	assert.Nil(t, err)
 
@@ -134,7 +218,7 @@ var content = `+"`"+`{
 		t.Fatalf("unable to create generate object: %v", err)
 	}
 
-	storePkg, err := gen.generateStorePackage("github.com/mvoorberg/sqlxgen-example")
+	storePkg, err := gen.generateStorePackage("github.com/mvoorberg/sqlxgen-example", nil)
 
 	assert.Nil(t, err)
 
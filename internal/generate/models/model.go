@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"path"
 	"reflect"
+	"slices"
 	"strings"
 	"text/template"
 
@@ -32,6 +33,11 @@ type model struct {
 	CamelName        string           `json:"camel_name"`
 	Fields           []types.Field    `json:"fields"`
 	PkFields         []types.Field    `json:"pk_fields"`
+	BelongsTo        []relation       `json:"belongs_to"`
+	HasMany          []relation       `json:"has_many"`
+	UniqueKeys       []uniqueKey      `json:"unique_keys"`
+	SortKeys         []string         `json:"sort_keys"`
+	WhereFields      []whereField     `json:"where_fields"`
 	Table            introspect.Table `json:"table"`
 }
 
@@ -39,13 +45,14 @@ func (m model) getImports() []string {
 	uniqueImports := mapset.NewSet()
 
 	for _, f := range m.Fields {
-		if f.Type.Import == "" {
-			continue
+		for _, imp := range f.Type.Imports() {
+			uniqueImports.Add(imp)
 		}
-
-		uniqueImports.Add(f.Type.Import)
 	}
 
+	// the columns of the model are store columns
+	uniqueImports.Add(m.StorePackageDir)
+
 	importSlice := uniqueImports.ToSlice()
 
 	imports := array.Map(
@@ -55,6 +62,8 @@ func (m model) getImports() []string {
 		},
 	)
 
+	slices.Sort(imports)
+
 	return imports
 }
 
@@ -66,39 +75,7 @@ func (m model) generate(
 ) error {
 	slog.Debug("generating model", "table", m.Table.TableName, "model", m.PascalName)
 
-	helpers := template.FuncMap{
-		"isLast": func(index int, array interface{}) bool {
-			return index == reflect.ValueOf(array).Len()-1
-		},
-		"isFirst": func(index int) bool {
-			return index == 0
-		},
-		"ToUpper": strings.ToUpper,
-		"ToJson": func(v interface{}) string {
-			b, _ := json.Marshal(v)
-
-			return string(b)
-		},
-		"GetOption": func(opt string) string {
-			optVal, ok := options[opt]
-			if !ok {
-				return ""
-			}
-			return optVal
-		},
-		"OptionContains": func(opt string, needle string) string {
-			optVal, ok := options[opt]
-			if ok {
-				optSlice := strings.Split(optVal, ",")
-				for _, opt := range optSlice {
-					if opt == needle {
-						return "true"
-					}
-				}
-			}
-			return "false"
-		},
-	}
+	helpers := modelHelpers(options)
 
 	tmpl, err := template.New("model").Funcs(helpers).Parse(modelTemplate)
 
@@ -106,23 +83,15 @@ func (m model) generate(
 		return errorx.IllegalFormat.Wrap(err, "unable to parse model template")
 	}
 
-	imports := m.getImports()
+	data, err := m.templateData(packageName, options)
 
-	insertFields, updateFields, selectFields := distinguishFields(m.Fields)
+	if err != nil {
+		return err
+	}
 
 	var modelFileBuffer bytes.Buffer
 
-	err = tmpl.Execute(
-		&modelFileBuffer,
-		map[string]interface{}{
-			"PackageName":  packageName,
-			"Imports":      imports,
-			"Model":        m,
-			"InsertFields": insertFields,
-			"UpdateFields": updateFields,
-			"SelectFields": selectFields,
-		},
-	)
+	err = tmpl.Execute(&modelFileBuffer, data)
 
 	if err != nil {
 		return errorx.InternalError.Wrap(err, "unable to execute model template")
@@ -151,6 +120,98 @@ func (m model) generate(
 	return nil
 }
 
+// generateExtra renders an extra template of the table next to the model.
+func (m model) generateExtra(
+	extra types.ExtraTemplate,
+	packageName string,
+	genDir string,
+	options map[string]string,
+) error {
+	data, err := m.templateData(packageName, options)
+
+	if err != nil {
+		return err
+	}
+
+	fileName, content, err := extra.Render(modelHelpers(options), data)
+
+	if err != nil {
+		return errorx.Decorate(err, "unable to render %s for table %s", extra.Name, m.Table.TableName)
+	}
+
+	pen := m.WriterCreator(path.Join(genDir, fileName), content)
+
+	err = pen.Write()
+
+	if err != nil {
+		return errorx.InternalError.Wrap(err, "unable to write %s file", fileName)
+	}
+
+	return nil
+}
+
+func (m model) templateData(packageName string, options map[string]string) (map[string]interface{}, error) {
+	imports := m.getImports()
+
+	insertFields, updateFields, selectFields := distinguishFields(m.Fields)
+
+	upsertKeys, err := newUpsertKeys(m, options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"PackageName":  packageName,
+		"Imports":      imports,
+		"Model":        m,
+		"InsertFields": insertFields,
+		"UpdateFields": updateFields,
+		"SelectFields": selectFields,
+		"UpsertKeys":   upsertKeys,
+		"Options":      options,
+	}
+
+	return data, nil
+}
+
+func modelHelpers(options map[string]string) template.FuncMap {
+	return template.FuncMap{
+		"isLast": func(index int, array interface{}) bool {
+			return index == reflect.ValueOf(array).Len()-1
+		},
+		"isFirst": func(index int) bool {
+			return index == 0
+		},
+		"ToUpper":    strings.ToUpper,
+		"ImportSpec": types.ImportSpec,
+		"ToJson": func(v interface{}) string {
+			b, _ := json.Marshal(v)
+
+			return string(b)
+		},
+		"GetOption": func(opt string) string {
+			optVal, ok := options[opt]
+			if !ok {
+				return ""
+			}
+			return optVal
+		},
+		"OptionContains": func(opt string, needle string) string {
+			optVal, ok := options[opt]
+			if ok {
+				optSlice := strings.Split(optVal, ",")
+				for _, opt := range optSlice {
+					if opt == needle {
+						return "true"
+					}
+				}
+			}
+			return "false"
+		},
+	}
+}
+
 func newModel(
 	writerCreator writer.Creator,
 	translate types.Translate,
@@ -178,6 +239,8 @@ func newModel(
 		return model{}, err
 	}
 
+	translate = types.ForTable(translate, table.SchemaName, table.TableName)
+
 	fields := make([]types.Field, len(table.Columns))
 
 	pkFields := make([]types.Field, 0)
@@ -216,6 +279,14 @@ func newModel(
 		Fields:           fields,
 		PkFields:         pkFields,
 		Table:            table,
+		SortKeys:         newSortKeys(fields),
+		WhereFields:      newWhereFields(fields),
+	}
+
+	m.UniqueKeys, err = newUniqueKeys(m)
+
+	if err != nil {
+		return model{}, err
 	}
 
 	return m, nil
@@ -90,12 +90,12 @@ func TestModel_GetImports(t *testing.T) {
 		{
 			name: "actor",
 			t:    tables[0],
-			want: []string{},
+			want: []string{"gen/store"},
 		},
 		{
 			name: "movie",
 			t:    tables[1],
-			want: []string{"time"},
+			want: []string{"gen/store", "time"},
 		},
 	}
 
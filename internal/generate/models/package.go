@@ -1,7 +1,6 @@
 package models
 
 import (
-	"os"
 	"path/filepath"
 
 	"github.com/joomcode/errorx"
@@ -20,21 +19,25 @@ type Package struct {
 	GenDir           string
 	Models           []model
 	Options          map[string]string
+	// ExtraTemplates are rendered once per table
+	ExtraTemplates []types.ExtraTemplate
 }
 
 func (p Package) Generate() error {
-	err := os.MkdirAll(p.GenDir, 0755)
-
-	if err != nil {
-		return errorx.InitializationFailed.Wrap(err, "unable to create directory for models")
-	}
-
 	for _, m := range p.Models {
 		err := m.generate(p.ModelTemplate, p.PackageName, p.GenDir, p.Options)
 
 		if err != nil {
 			return err
 		}
+
+		for _, extra := range p.ExtraTemplates {
+			err := m.generateExtra(extra, p.PackageName, p.GenDir, p.Options)
+
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -76,6 +79,12 @@ func NewPackage(
 		models[index] = m
 	}
 
+	err = linkRelations(models)
+
+	if err != nil {
+		return Package{}, errorx.IllegalState.Wrap(err, "unable to link model relations")
+	}
+
 	p := Package{
 		ModelTemplate:    translate.ModelTemplate(),
 		StorePackageDir:  storePackageDir,
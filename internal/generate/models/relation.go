@@ -0,0 +1,251 @@
+package models
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/mvoorberg/sqlxgen/internal/generate/types"
+	"github.com/mvoorberg/sqlxgen/internal/introspect"
+	"github.com/mvoorberg/sqlxgen/internal/utils/array"
+	"github.com/mvoorberg/sqlxgen/internal/utils/casing"
+)
+
+type relation struct {
+	LoaderName     string           `json:"loader_name"`
+	ConstraintName string           `json:"constraint_name"`
+	PascalName     string           `json:"pascal_name"`
+	SchemaName     string           `json:"schema_name"`
+	TableName      string           `json:"table_name"`
+	SelectColumns  []string         `json:"select_columns"`
+	Conditions     []relationColumn `json:"conditions"`
+	NullableFields []types.Field    `json:"nullable_fields"`
+}
+
+type relationColumn struct {
+	Column string `json:"column"`
+	Param  string `json:"param"`
+}
+
+// linkRelations resolves the foreign keys of every model against the other
+// models of the package, so that BelongsTo and HasMany loaders can be generated.
+// Foreign keys referencing tables outside the package are skipped.
+func linkRelations(models []model) error {
+	index := make(map[string]int)
+
+	for i, m := range models {
+		index[qualifiedName(m.Table.SchemaName, m.Table.TableName)] = i
+	}
+
+	for i := range models {
+		models[i].BelongsTo = make([]relation, 0)
+
+		models[i].HasMany = make([]relation, 0)
+	}
+
+	for i, child := range models {
+		for _, fk := range child.Table.ForeignKeys {
+			parentIndex, ok := index[qualifiedName(fk.RefSchemaName, fk.RefTableName)]
+
+			if !ok {
+				slog.Debug(
+					"skipping foreign key to table outside of models",
+					"table", child.Table.TableName,
+					"constraint", fk.ConstraintName,
+				)
+
+				continue
+			}
+
+			if len(fk.Columns) == 0 || len(fk.Columns) != len(fk.RefColumns) {
+				slog.Warn("skipping malformed foreign key", "table", child.Table.TableName, "constraint", fk.ConstraintName)
+
+				continue
+			}
+
+			parent := models[parentIndex]
+
+			belongsTo, err := newBelongsTo(child, parent, fk)
+
+			if err != nil {
+				return err
+			}
+
+			hasMany, err := newHasMany(child, parent, fk)
+
+			if err != nil {
+				return err
+			}
+
+			models[i].BelongsTo = append(models[i].BelongsTo, belongsTo)
+
+			models[parentIndex].HasMany = append(models[parentIndex].HasMany, hasMany)
+		}
+	}
+
+	for i := range models {
+		disambiguate(models[i].BelongsTo, models[i].HasMany)
+	}
+
+	return nil
+}
+
+func newBelongsTo(child model, parent model, fk introspect.ForeignKey) (relation, error) {
+	loaderName := parent.PascalName
+
+	if len(fk.Columns) == 1 && strings.HasSuffix(fk.Columns[0], "_id") {
+		name, err := casing.PascalCase(strings.TrimSuffix(fk.Columns[0], "_id"))
+
+		if err != nil {
+			return relation{}, err
+		}
+
+		loaderName = name
+	}
+
+	conditions := make([]relationColumn, len(fk.Columns))
+
+	for i, column := range fk.Columns {
+		conditions[i] = relationColumn{Column: fk.RefColumns[i], Param: column}
+	}
+
+	nullableFields := array.Filter(
+		child.Fields,
+		func(f types.Field, _ int) bool {
+			return (f.Type.IsPointer || f.Type.IsNull()) && contains(fk.Columns, f.Column.ColumnName)
+		},
+	)
+
+	r := relation{
+		LoaderName:     loaderName,
+		ConstraintName: fk.ConstraintName,
+		PascalName:     parent.PascalName,
+		SchemaName:     parent.Table.SchemaName,
+		TableName:      parent.Table.TableName,
+		SelectColumns:  selectColumns(parent),
+		Conditions:     conditions,
+		NullableFields: nullableFields,
+	}
+
+	return r, nil
+}
+
+func newHasMany(child model, parent model, fk introspect.ForeignKey) (relation, error) {
+	loaderName, err := casing.PascalCase(child.Table.TableName)
+
+	if err != nil {
+		return relation{}, err
+	}
+
+	conditions := make([]relationColumn, len(fk.Columns))
+
+	for i, column := range fk.Columns {
+		conditions[i] = relationColumn{Column: column, Param: fk.RefColumns[i]}
+	}
+
+	nullableFields := array.Filter(
+		parent.Fields,
+		func(f types.Field, _ int) bool {
+			return (f.Type.IsPointer || f.Type.IsNull()) && contains(fk.RefColumns, f.Column.ColumnName)
+		},
+	)
+
+	r := relation{
+		LoaderName:     loaderName,
+		ConstraintName: fk.ConstraintName,
+		PascalName:     child.PascalName,
+		SchemaName:     child.Table.SchemaName,
+		TableName:      child.Table.TableName,
+		SelectColumns:  selectColumns(child),
+		Conditions:     conditions,
+		NullableFields: nullableFields,
+	}
+
+	return r, nil
+}
+
+// disambiguate appends the foreign key columns to the loader names that
+// collide across the BelongsTo and HasMany loaders of a model, e.g. two foreign
+// keys from the same table to the same parent. A BelongsTo loader still named
+// like a HasMany loader, on a table referencing itself, gets a Parent suffix.
+func disambiguate(belongsTo []relation, hasMany []relation) {
+	counts := loaderNameCounts(belongsTo, hasMany)
+
+	for i, r := range belongsTo {
+		if counts[r.LoaderName] < 2 {
+			continue
+		}
+
+		belongsTo[i].LoaderName = withKeySuffix(r, func(c relationColumn) string { return c.Param })
+	}
+
+	for i, r := range hasMany {
+		if counts[r.LoaderName] < 2 {
+			continue
+		}
+
+		hasMany[i].LoaderName = withKeySuffix(r, func(c relationColumn) string { return c.Column })
+	}
+
+	hasManyNames := loaderNameCounts(hasMany)
+
+	for i, r := range belongsTo {
+		if hasManyNames[r.LoaderName] > 0 {
+			belongsTo[i].LoaderName = fmt.Sprintf("%sParent", r.LoaderName)
+		}
+	}
+}
+
+func loaderNameCounts(relations ...[]relation) map[string]int {
+	counts := make(map[string]int)
+
+	for _, each := range relations {
+		for _, r := range each {
+			counts[r.LoaderName]++
+		}
+	}
+
+	return counts
+}
+
+// withKeySuffix names the loader after the foreign key columns, picked from
+// the conditions of the relation by keyColumn.
+func withKeySuffix(r relation, keyColumn func(c relationColumn) string) string {
+	columns := array.Map(
+		r.Conditions,
+		func(c relationColumn, _ int) string {
+			return keyColumn(c)
+		},
+	)
+
+	suffix, err := casing.PascalCase(strings.Join(columns, "_"))
+
+	if err != nil {
+		return r.LoaderName
+	}
+
+	return fmt.Sprintf("%sBy%s", r.LoaderName, suffix)
+}
+
+func selectColumns(m model) []string {
+	return array.Map(
+		m.Fields,
+		func(f types.Field, _ int) string {
+			return f.Column.ColumnName
+		},
+	)
+}
+
+func qualifiedName(schemaName string, tableName string) string {
+	return fmt.Sprintf("%s.%s", schemaName, tableName)
+}
+
+func contains(haystack []string, needle string) bool {
+	for _, each := range haystack {
+		if each == needle {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,181 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/mvoorberg/sqlxgen/internal/generate/types"
+	"github.com/mvoorberg/sqlxgen/internal/introspect"
+	"github.com/mvoorberg/sqlxgen/internal/utils/array"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkRelations(t *testing.T) {
+	t.Parallel()
+
+	ft := types.NewFakeTranslate("", "")
+
+	tables := []introspect.Table{
+		{
+			SchemaName: "public",
+			TableName:  "actors",
+			Columns: introspect.Columns{
+				{ColumnName: "id", Type: "int8", PkOrdinalPosition: 1},
+			},
+			ForeignKeys: introspect.ForeignKeys{},
+		},
+		{
+			SchemaName: "public",
+			TableName:  "scenes",
+			Columns: introspect.Columns{
+				{ColumnName: "id", Type: "int8", PkOrdinalPosition: 1},
+				{ColumnName: "lead_id", Type: "int8"},
+				{ColumnName: "double_id", Type: "int8", Nullable: true},
+				{ColumnName: "studio_id", Type: "int8"},
+			},
+			ForeignKeys: introspect.ForeignKeys{
+				{
+					ConstraintName: "scenes_lead_id_fkey",
+					Columns:        []string{"lead_id"},
+					RefSchemaName:  "public",
+					RefTableName:   "actors",
+					RefColumns:     []string{"id"},
+				},
+				{
+					ConstraintName: "scenes_double_id_fkey",
+					Columns:        []string{"double_id"},
+					RefSchemaName:  "public",
+					RefTableName:   "actors",
+					RefColumns:     []string{"id"},
+				},
+				{
+					ConstraintName: "scenes_studio_id_fkey",
+					Columns:        []string{"studio_id"},
+					RefSchemaName:  "public",
+					RefTableName:   "studios",
+					RefColumns:     []string{"id"},
+				},
+			},
+		},
+	}
+
+	models := make([]model, len(tables))
+
+	for i, table := range tables {
+		m, err := newModel(nil, ft, "gen/store", "github.com/john-doe/gen/store", table)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		models[i] = m
+	}
+
+	err := linkRelations(models)
+
+	assert.Nil(t, err)
+
+	loaderNames := func(relations []relation) []string {
+		return array.Map(
+			relations,
+			func(r relation, _ int) string {
+				return r.LoaderName
+			},
+		)
+	}
+
+	actor, scene := models[0], models[1]
+
+	assert.Empty(t, actor.BelongsTo)
+	assert.Equal(t, []string{"ScenesByLeadId", "ScenesByDoubleId"}, loaderNames(actor.HasMany))
+
+	assert.Empty(t, scene.HasMany)
+	assert.Equal(t, []string{"Lead", "Double"}, loaderNames(scene.BelongsTo))
+
+	assert.Equal(t, []relationColumn{{Column: "id", Param: "lead_id"}}, scene.BelongsTo[0].Conditions)
+	assert.Equal(t, []relationColumn{{Column: "double_id", Param: "id"}}, actor.HasMany[1].Conditions)
+	assert.Equal(t, []string{"id", "lead_id", "double_id", "studio_id"}, actor.HasMany[0].SelectColumns)
+}
+
+func TestLinkRelations_Collisions(t *testing.T) {
+	t.Parallel()
+
+	ft := types.NewFakeTranslate("", "")
+
+	tables := []introspect.Table{
+		{
+			SchemaName: "public",
+			TableName:  "crews",
+			Columns: introspect.Columns{
+				{ColumnName: "id", Type: "int8", PkOrdinalPosition: 1},
+				{ColumnName: "movies_id", Type: "int8"},
+			},
+			ForeignKeys: introspect.ForeignKeys{
+				{
+					ConstraintName: "crews_movies_id_fkey",
+					Columns:        []string{"movies_id"},
+					RefSchemaName:  "public",
+					RefTableName:   "movies",
+					RefColumns:     []string{"id"},
+				},
+			},
+		},
+		{
+			SchemaName: "public",
+			TableName:  "movies",
+			Columns: introspect.Columns{
+				{ColumnName: "id", Type: "int8", PkOrdinalPosition: 1},
+				{ColumnName: "crew_id", Type: "int8"},
+			},
+			ForeignKeys: introspect.ForeignKeys{
+				{
+					ConstraintName: "movies_crew_id_fkey",
+					Columns:        []string{"crew_id"},
+					RefSchemaName:  "public",
+					RefTableName:   "crews",
+					RefColumns:     []string{"id"},
+				},
+			},
+		},
+		{
+			SchemaName: "public",
+			TableName:  "nodes",
+			Columns: introspect.Columns{
+				{ColumnName: "id", Type: "int8", PkOrdinalPosition: 1},
+				{ColumnName: "nodes_id", Type: "int8", Nullable: true},
+			},
+			ForeignKeys: introspect.ForeignKeys{
+				{
+					ConstraintName: "nodes_nodes_id_fkey",
+					Columns:        []string{"nodes_id"},
+					RefSchemaName:  "public",
+					RefTableName:   "nodes",
+					RefColumns:     []string{"id"},
+				},
+			},
+		},
+	}
+
+	models := make([]model, len(tables))
+
+	for i, table := range tables {
+		m, err := newModel(nil, ft, "gen/store", "github.com/john-doe/gen/store", table)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		models[i] = m
+	}
+
+	err := linkRelations(models)
+
+	assert.Nil(t, err)
+
+	crew, node := models[0], models[2]
+
+	assert.Equal(t, "MoviesByMoviesId", crew.BelongsTo[0].LoaderName)
+	assert.Equal(t, "MoviesByCrewId", crew.HasMany[0].LoaderName)
+
+	assert.Equal(t, "NodesByNodesIdParent", node.BelongsTo[0].LoaderName)
+	assert.Equal(t, "NodesByNodesId", node.HasMany[0].LoaderName)
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/mvoorberg/sqlxgen/internal/generate/types"
+)
+
+// unorderedTypes are the database types without a meaningful order, or that
+// can't be compared in a row value.
+var unorderedTypes = map[string]bool{
+	"":            true,
+	"interface{}": true,
+	"array":       true,
+	"blob":        true,
+	"bytea":       true,
+	"geography":   true,
+	"geometry":    true,
+	"hstore":      true,
+	"json":        true,
+	"jsonb":       true,
+	"point":       true,
+	"tsquery":     true,
+	"tsvector":    true,
+	"xml":         true,
+}
+
+// newSortKeys lists the columns a keyset pagination can order the model on,
+// the not null columns of an ordered type. A null would drop the row from
+// the comparison of the keyset.
+func newSortKeys(fields []types.Field) []string {
+	keys := make([]string, 0)
+
+	for _, f := range fields {
+		if f.Column.Nullable || !isOrdered(f) {
+			continue
+		}
+
+		keys = append(keys, f.Column.ColumnName)
+	}
+
+	return keys
+}
+
+// isOrdered reports whether the values of the field can be compared, arrays
+// and store.Json fields can't.
+func isOrdered(f types.Field) bool {
+	dbType := strings.ToLower(f.Column.Type)
+
+	if f.Column.IsArray || f.Type.StoreImport != "" {
+		return false
+	}
+
+	return !unorderedTypes[dbType] && !strings.HasSuffix(dbType, "blob")
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/mvoorberg/sqlxgen/internal/generate/types"
+	"github.com/mvoorberg/sqlxgen/internal/introspect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSortKeys(t *testing.T) {
+	t.Parallel()
+
+	fields := []types.Field{
+		{Column: introspect.Column{ColumnName: "id", Type: "int8"}},
+		{Column: introspect.Column{ColumnName: "title", Type: "text"}},
+		{Column: introspect.Column{ColumnName: "release_date", Type: "date", Nullable: true}},
+		{Column: introspect.Column{ColumnName: "keywords", Type: "text", IsArray: true}},
+		{Column: introspect.Column{ColumnName: "details", Type: "jsonb"}},
+		{Column: introspect.Column{ColumnName: "poster", Type: "LONGBLOB"}},
+		{
+			Column: introspect.Column{ColumnName: "settings", Type: "text"},
+			Type:   types.GoType{GoType: "store.Json[Settings]", StoreImport: "gen/store"},
+		},
+		{Column: introspect.Column{ColumnName: "created_at", Type: "timestamp with time zone"}},
+	}
+
+	assert.Equal(t, []string{"id", "title", "created_at"}, newSortKeys(fields))
+}
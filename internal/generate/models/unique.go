@@ -0,0 +1,125 @@
+package models
+
+import (
+	"fmt"
+	"go/token"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/mvoorberg/sqlxgen/internal/generate/types"
+	"github.com/mvoorberg/sqlxgen/internal/utils/casing"
+)
+
+// uniqueKey is a unique key of the model, FindBy, UpdateBy and DeleteBy
+// methods named after its columns are generated for it.
+type uniqueKey struct {
+	ConstraintName string     `json:"constraint_name"`
+	MethodName     string     `json:"method_name"`
+	Fields         []keyField `json:"fields"`
+}
+
+// reservedParams are the names taken in the generated unique key functions.
+var reservedParams = map[string]bool{
+	"context":  true,
+	"ctx":      true,
+	"db":       true,
+	"instance": true,
+	"key":      true,
+}
+
+// keyField is a column of a unique key, passed as the Param argument of the
+// generated methods and assigned to the field as Value.
+type keyField struct {
+	types.Field
+	Param     string `json:"param"`
+	ParamType string `json:"param_type"`
+	Value     string `json:"value"`
+}
+
+// newUniqueKeys lists the unique keys of the table, the keys over the same
+// columns as a previous one are skipped, their methods would collide. Params
+// clashing with a keyword or another identifier of the methods are suffixed.
+func newUniqueKeys(m model) ([]uniqueKey, error) {
+	keys := make([]uniqueKey, 0)
+
+	receiverName := m.CamelName[0:1]
+
+	for _, uk := range m.Table.UniqueKeys {
+		fields := make([]keyField, 0)
+
+		names := make([]string, 0)
+
+		for _, column := range uk.Columns {
+			idx := slices.IndexFunc(
+				m.Fields,
+				func(f types.Field) bool {
+					return f.Column.ColumnName == column
+				},
+			)
+
+			if idx < 0 {
+				break
+			}
+
+			f := m.Fields[idx]
+
+			param, err := casing.CamelCase(column)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if token.IsKeyword(param) || reservedParams[param] || param == receiverName || param == m.StorePackageName {
+				param += "Value"
+			}
+
+			paramType, value := valueType(f.Type), param
+
+			if f.Type.IsPointerType() {
+				value = "&" + param
+			}
+
+			if f.Type.IsNull() {
+				value = fmt.Sprintf("%s{V: %s, Valid: true}", f.Type.GoType, param)
+			}
+
+			fields = append(
+				fields,
+				keyField{
+					Field:     f,
+					Param:     param,
+					ParamType: paramType,
+					Value:     value,
+				},
+			)
+
+			names = append(names, f.Name)
+		}
+
+		if len(fields) != len(uk.Columns) {
+			slog.Warn("skipping unique key over unknown columns", "table", m.Table.TableName, "constraint", uk.ConstraintName)
+
+			continue
+		}
+
+		methodName := strings.Join(names, "And")
+
+		if slices.ContainsFunc(keys, func(k uniqueKey) bool { return k.MethodName == methodName }) {
+			slog.Debug("skipping duplicate unique key", "table", m.Table.TableName, "constraint", uk.ConstraintName)
+
+			continue
+		}
+
+		keys = append(
+			keys,
+			uniqueKey{
+				ConstraintName: uk.ConstraintName,
+				MethodName:     methodName,
+				Fields:         fields,
+			},
+		)
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,120 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/mvoorberg/sqlxgen/internal/generate/types"
+	"github.com/mvoorberg/sqlxgen/internal/introspect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewUniqueKeys(t *testing.T) {
+	t.Parallel()
+
+	ft := types.NewFakeTranslate("", "")
+
+	testCases := []struct {
+		name  string
+		table introspect.Table
+		want  []string
+		keys  [][]keyField
+	}{
+		{
+			name: "single and composite keys",
+			table: introspect.Table{
+				SchemaName: "public",
+				TableName:  "movies",
+				Columns: introspect.Columns{
+					{ColumnName: "id", Type: "int8", IsSequence: true, PkOrdinalPosition: 1},
+					{ColumnName: "title", Type: "text"},
+					{ColumnName: "release_date", Type: "date"},
+				},
+				UniqueKeys: introspect.UniqueKeys{
+					{ConstraintName: "movies_title_key", Columns: []string{"title"}},
+					{ConstraintName: "movies_title_release_date_key", Columns: []string{"title", "release_date"}},
+					{ConstraintName: "movies_title_idx", Columns: []string{"title"}},
+				},
+			},
+			want: []string{"Title", "TitleAndReleaseDate"},
+			keys: [][]keyField{
+				{{Param: "title", ParamType: "string"}},
+				{{Param: "title", ParamType: "string"}, {Param: "releaseDate", ParamType: "time.Time"}},
+			},
+		},
+		{
+			name: "params clashing with identifiers",
+			table: introspect.Table{
+				SchemaName: "public",
+				TableName:  "items",
+				Columns: introspect.Columns{
+					{ColumnName: "type", Type: "text"},
+					{ColumnName: "db", Type: "text"},
+					{ColumnName: "i", Type: "text"},
+					{ColumnName: "ctx", Type: "text"},
+					{ColumnName: "key", Type: "text"},
+				},
+				UniqueKeys: introspect.UniqueKeys{
+					{ConstraintName: "items_type_db_i_key", Columns: []string{"type", "db", "i"}},
+					{ConstraintName: "items_ctx_key_key", Columns: []string{"ctx", "key"}},
+				},
+			},
+			want: []string{"TypeAndDbAndI", "CtxAndKey"},
+			keys: [][]keyField{
+				{
+					{Param: "typeValue", ParamType: "string"},
+					{Param: "dbValue", ParamType: "string"},
+					{Param: "iValue", ParamType: "string"},
+				},
+				{
+					{Param: "ctxValue", ParamType: "string"},
+					{Param: "keyValue", ParamType: "string"},
+				},
+			},
+		},
+		{
+			name: "unknown column",
+			table: introspect.Table{
+				SchemaName: "public",
+				TableName:  "actors",
+				Columns:    introspect.Columns{{ColumnName: "name", Type: "text"}},
+				UniqueKeys: introspect.UniqueKeys{
+					{ConstraintName: "actors_email_key", Columns: []string{"email"}},
+				},
+			},
+			want: []string{},
+			keys: [][]keyField{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := newModel(nil, ft, "gen/store", "store", testCase.table)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			methodNames := make([]string, len(m.UniqueKeys))
+
+			keys := make([][]keyField, len(m.UniqueKeys))
+
+			for idx, key := range m.UniqueKeys {
+				methodNames[idx] = key.MethodName
+
+				keys[idx] = make([]keyField, len(key.Fields))
+
+				for fieldIdx, f := range key.Fields {
+					keys[idx][fieldIdx] = keyField{Param: f.Param, ParamType: f.ParamType}
+				}
+			}
+
+			assert.Equal(t, testCase.want, methodNames)
+
+			assert.Equal(t, testCase.keys, keys)
+		})
+	}
+}
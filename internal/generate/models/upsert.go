@@ -0,0 +1,96 @@
+package models
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/mvoorberg/sqlxgen/internal/generate/types"
+	"github.com/mvoorberg/sqlxgen/internal/utils/casing"
+)
+
+// upsertKey is a key an upsert can conflict on, the primary key has no
+// constraint name.
+type upsertKey struct {
+	ConstraintName string   `json:"constraint_name"`
+	PascalName     string   `json:"pascal_name"`
+	Columns        []string `json:"columns"`
+	InsertColumns  []string `json:"insert_columns"`
+	UpdateColumns  []string `json:"update_columns"`
+}
+
+// newUpsertKeys lists the primary key, when the table has one, followed by
+// the unique keys of the table. Created date fields keep their value on
+// conflict.
+func newUpsertKeys(m model, options map[string]string) ([]upsertKey, error) {
+	keys := make([]upsertKey, 0)
+
+	createdDateFields := strings.Split(options["createdDateFields"], ",")
+
+	fields := slices.DeleteFunc(
+		slices.Clone(m.Fields),
+		func(f types.Field) bool {
+			return f.Column.Generated
+		},
+	)
+
+	if len(m.PkFields) > 0 && m.PkFields[0].Column.PkOrdinalPosition > 0 {
+		columns := make([]string, len(m.PkFields))
+
+		for idx, f := range m.PkFields {
+			columns[idx] = f.Column.ColumnName
+		}
+
+		keys = append(keys, newUpsertKey(fields, createdDateFields, "", "", columns))
+	}
+
+	for _, uk := range m.Table.UniqueKeys {
+		pascalName, err := casing.PascalCase(uk.ConstraintName)
+
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, newUpsertKey(fields, createdDateFields, uk.ConstraintName, pascalName, uk.Columns))
+	}
+
+	return keys, nil
+}
+
+// newUpsertKey inserts the key columns and the columns the database doesn't
+// fill in, the columns outside the key and the primary key are updated on
+// conflict.
+func newUpsertKey(
+	fields []types.Field,
+	createdDateFields []string,
+	constraintName string,
+	pascalName string,
+	columns []string,
+) upsertKey {
+	insertColumns := make([]string, 0)
+
+	updateColumns := make([]string, 0)
+
+	for _, f := range fields {
+		name := f.Column.ColumnName
+
+		inKey := slices.Contains(columns, name)
+
+		if f.Column.IsSequence && !inKey {
+			continue
+		}
+
+		insertColumns = append(insertColumns, name)
+
+		if !inKey && f.Column.PkOrdinalPosition == 0 && !slices.Contains(createdDateFields, name) {
+			updateColumns = append(updateColumns, name)
+		}
+	}
+
+	return upsertKey{
+		ConstraintName: constraintName,
+		PascalName:     pascalName,
+		Columns:        columns,
+		InsertColumns:  insertColumns,
+		UpdateColumns:  updateColumns,
+	}
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/mvoorberg/sqlxgen/internal/generate/types"
+	"github.com/mvoorberg/sqlxgen/internal/introspect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewUpsertKeys(t *testing.T) {
+	t.Parallel()
+
+	ft := types.NewFakeTranslate("", "")
+
+	testCases := []struct {
+		name    string
+		table   introspect.Table
+		options map[string]string
+		want    []upsertKey
+	}{
+		{
+			name: "primary and unique keys",
+			table: introspect.Table{
+				SchemaName: "public",
+				TableName:  "actors",
+				Columns: introspect.Columns{
+					{ColumnName: "id", Type: "int8", IsSequence: true, PkOrdinalPosition: 1},
+					{ColumnName: "name", Type: "text"},
+					{ColumnName: "name_search", Type: "tsvector", Generated: true},
+					{ColumnName: "created_at", Type: "timestamptz"},
+					{ColumnName: "updated_at", Type: "timestamptz"},
+				},
+				UniqueKeys: introspect.UniqueKeys{
+					{ConstraintName: "actors_name_key", Columns: []string{"name"}},
+				},
+			},
+			options: map[string]string{
+				"createdDateFields": "created_at",
+				"updatedDateFields": "updated_at",
+			},
+			want: []upsertKey{
+				{
+					ConstraintName: "",
+					PascalName:     "",
+					Columns:        []string{"id"},
+					InsertColumns:  []string{"id", "name", "created_at", "updated_at"},
+					UpdateColumns:  []string{"name", "updated_at"},
+				},
+				{
+					ConstraintName: "actors_name_key",
+					PascalName:     "ActorsNameKey",
+					Columns:        []string{"name"},
+					InsertColumns:  []string{"name", "created_at", "updated_at"},
+					UpdateColumns:  []string{"updated_at"},
+				},
+			},
+		},
+		{
+			name: "no primary key",
+			table: introspect.Table{
+				SchemaName: "public",
+				TableName:  "movie_titles",
+				Columns: introspect.Columns{
+					{ColumnName: "id", Type: "int8"},
+					{ColumnName: "title", Type: "text"},
+				},
+			},
+			options: map[string]string{},
+			want:    []upsertKey{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := newModel(nil, ft, "gen/store", "github.com/john-doe/gen/store", testCase.table)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got, err := newUpsertKeys(m, testCase.options)
+
+			assert.Nil(t, err)
+
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/mvoorberg/sqlxgen/internal/generate/types"
+)
+
+// whereField is a field of the model a store.Filter of ValueType is generated
+// for, a store.TextFilter when Text.
+type whereField struct {
+	types.Field
+	ValueType string `json:"value_type"`
+	Text      bool   `json:"text"`
+}
+
+// newWhereFields lists the fields of an ordered type, the others can't be
+// compared to a value.
+func newWhereFields(fields []types.Field) []whereField {
+	whereFields := make([]whereField, 0)
+
+	for _, f := range fields {
+		if !isOrdered(f) {
+			continue
+		}
+
+		valueType := valueType(f.Type)
+
+		whereFields = append(
+			whereFields,
+			whereField{
+				Field:     f,
+				ValueType: valueType,
+				Text:      valueType == "string",
+			},
+		)
+	}
+
+	return whereFields
+}
+
+// valueType is the type of the values of a field, without the pointer or
+// the sql.Null wrapping it.
+func valueType(goType types.GoType) string {
+	if goType.IsNull() {
+		return strings.TrimSuffix(strings.TrimPrefix(goType.GoType, "sql.Null["), "]")
+	}
+
+	return strings.TrimPrefix(goType.GoType, "*")
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/mvoorberg/sqlxgen/internal/generate/types"
+	"github.com/mvoorberg/sqlxgen/internal/introspect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewWhereFields(t *testing.T) {
+	t.Parallel()
+
+	fields := []types.Field{
+		{
+			Name:   "Id",
+			Column: introspect.Column{ColumnName: "id", Type: "int8"},
+			Type:   types.GoType{GoType: "*int64", IsPointer: true},
+		},
+		{
+			Name:   "Title",
+			Column: introspect.Column{ColumnName: "title", Type: "text"},
+			Type:   types.GoType{GoType: "string"},
+		},
+		{
+			Name:   "ReleaseDate",
+			Column: introspect.Column{ColumnName: "release_date", Type: "date", Nullable: true},
+			Type:   types.GoType{GoType: "sql.Null[time.Time]", Import: "time"},
+		},
+		{
+			Name:   "Keywords",
+			Column: introspect.Column{ColumnName: "keywords", Type: "text", IsArray: true},
+			Type:   types.GoType{GoType: "*pq.StringArray", IsPointer: true},
+		},
+		{
+			Name:   "Details",
+			Column: introspect.Column{ColumnName: "details", Type: "jsonb"},
+			Type:   types.GoType{GoType: "*string", IsPointer: true},
+		},
+	}
+
+	got := newWhereFields(fields)
+
+	assert.Equal(
+		t,
+		[]whereField{
+			{Field: fields[0], ValueType: "int64"},
+			{Field: fields[1], ValueType: "string", Text: true},
+			{Field: fields[2], ValueType: "time.Time"},
+		},
+		got,
+	)
+}
@@ -81,6 +81,19 @@ func infer(storePackageDir string, column introspect.Column) (types.GoType, erro
 
 		return goType, nil
 
+	case "enum":
+		goType.GoType = "*string"
+
+		if column.EnumName != "" {
+			_, storePkg := filepath.Split(storePackageDir)
+
+			goType.GoType = fmt.Sprintf("*%s.%s", storePkg, column.EnumName)
+
+			goType.Import = storePackageDir
+		}
+
+		return goType, nil
+
 	case "set":
 		goType.GoType = "*string"
 
@@ -274,6 +274,22 @@ func TestInfer(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			name: "enum",
+			column: introspect.Column{
+				ColumnName: "c1",
+				Type:       "enum",
+				EnumName:   "MovieStatus",
+				EnumValues: introspect.EnumValues{"Released", "Rumored"},
+			},
+			want: types.GoType{
+				DbType:    "enum",
+				GoType:    "*store.MovieStatus",
+				IsPointer: true,
+				Import:    "github.com/john-doe/gen/store",
+			},
+			err: nil,
+		},
 		{
 			name: "set",
 			column: introspect.Column{
@@ -28,6 +28,10 @@ func fromSingle(storePackageDir string, column introspect.Column) (types.GoType,
 		IsPointer: true,
 	}
 
+	if len(column.EnumValues) > 0 {
+		return fromEnum(storePackageDir, column)
+	}
+
 	switch column.Type {
 	case "serial", "serial4", "pg_catalog.serial4":
 		goType.GoType = "*int32"
@@ -121,14 +125,14 @@ func fromSingle(storePackageDir string, column introspect.Column) (types.GoType,
 
 		return goType, nil
 
-	case "date", "time", "timetz", "timestamp", "timestamptz", "pg_catalog.time", "pg_catalog.timetz", "pg_catalog.date", "pg_catalog.timestamp", "pg_catalog.timestamptz":
+	case "date", "time", "timetz", "timestamp", "timestamptz", "pg_catalog.time", "pg_catalog.timetz", "pg_catalog.date", "pg_catalog.timestamp", "pg_catalog.timestamptz", "time with time zone", "time without time zone", "timestamp with time zone", "timestamp without time zone":
 		goType.Import = "time"
 
 		goType.GoType = "*time.Time"
 
 		return goType, nil
 
-	case "text", "string", "citext", "name", "bpchar", "tsquery", "varchar", "pg_catalog.varchar", "pg_catalog.bpchar", "pg_catalog.tsquery":
+	case "text", "string", "citext", "name", "bpchar", "tsquery", "varchar", "pg_catalog.varchar", "pg_catalog.bpchar", "pg_catalog.tsquery", "character", "character varying":
 		goType.GoType = "*string"
 
 		return goType, nil
@@ -176,6 +180,28 @@ func fromSingle(storePackageDir string, column introspect.Column) (types.GoType,
 	return goType, nil
 }
 
+// fromEnum maps an enum column to the type generated in the store package,
+// falling back to a plain string when the enum was not resolved.
+func fromEnum(storePackageDir string, column introspect.Column) (types.GoType, error) {
+	goType := types.GoType{
+		DbType:    column.Type,
+		GoType:    "*string",
+		IsPointer: true,
+	}
+
+	if column.EnumName == "" {
+		return goType, nil
+	}
+
+	_, storePkg := filepath.Split(storePackageDir)
+
+	goType.GoType = fmt.Sprintf("*%s.%s", storePkg, column.EnumName)
+
+	goType.Import = storePackageDir
+
+	return goType, nil
+}
+
 func fromArray(column introspect.Column) (types.GoType, error) {
 	goType := types.GoType{
 		DbType:    column.Type,
@@ -253,12 +279,12 @@ func fromArray(column introspect.Column) (types.GoType, error) {
 
 		return goType, nil
 
-	case "date", "time", "timetz", "timestamp", "timestamptz", "pg_catalog.time", "pg_catalog.timetz", "pg_catalog.date", "pg_catalog.timestamp", "pg_catalog.timestamptz":
+	case "date", "time", "timetz", "timestamp", "timestamptz", "pg_catalog.time", "pg_catalog.timetz", "pg_catalog.date", "pg_catalog.timestamp", "pg_catalog.timestamptz", "time with time zone", "time without time zone", "timestamp with time zone", "timestamp without time zone":
 		goType.GoType = "*pq.GenericArray"
 
 		return goType, nil
 
-	case "text", "string", "citext", "name", "tsquery", "pg_catalog.text", "pg_catalog.varchar", "pg_catalog.bpchar", "pg_catalog.tsquery":
+	case "text", "string", "citext", "name", "tsquery", "pg_catalog.text", "pg_catalog.varchar", "pg_catalog.bpchar", "pg_catalog.tsquery", "character", "character varying":
 		goType.GoType = "*pq.StringArray"
 
 		return goType, nil
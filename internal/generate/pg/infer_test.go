@@ -184,6 +184,25 @@ func TestFromSingle(t *testing.T) {
 				Import:    "time",
 			},
 		},
+		{
+			name:   "timestamp with time zone",
+			column: introspect.Column{Type: "timestamp with time zone"},
+			want: types.GoType{
+				DbType:    "timestamp with time zone",
+				GoType:    "*time.Time",
+				IsPointer: true,
+				Import:    "time",
+			},
+		},
+		{
+			name:   "character varying",
+			column: introspect.Column{Type: "character varying"},
+			want: types.GoType{
+				DbType:    "character varying",
+				GoType:    "*string",
+				IsPointer: true,
+			},
+		},
 		{
 			name:   "interval",
 			column: introspect.Column{Type: "interval"},
@@ -715,6 +734,32 @@ func TestFromSingle(t *testing.T) {
 				IsPointer: true,
 			},
 		},
+		{
+			name: "enum",
+			column: introspect.Column{
+				Type:       "mpaa_rating",
+				EnumName:   "MpaaRating",
+				EnumValues: introspect.EnumValues{"G", "PG", "PG-13"},
+			},
+			want: types.GoType{
+				DbType:    "mpaa_rating",
+				GoType:    "*store.MpaaRating",
+				IsPointer: true,
+				Import:    "github.com/john-doe/gen/store",
+			},
+		},
+		{
+			name: "unresolved enum",
+			column: introspect.Column{
+				Type:       "mpaa_rating",
+				EnumValues: introspect.EnumValues{"G", "PG", "PG-13"},
+			},
+			want: types.GoType{
+				DbType:    "mpaa_rating",
+				GoType:    "*string",
+				IsPointer: true,
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -0,0 +1,189 @@
+package plugin
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os/exec"
+	"strings"
+
+	"github.com/joomcode/errorx"
+	"github.com/mvoorberg/sqlxgen/internal/generate/types"
+	"github.com/mvoorberg/sqlxgen/internal/introspect"
+)
+
+// Version of the document written to the stdin of a plugin, it changes
+// whenever a field is removed or changes meaning.
+const Version = "1"
+
+// Plugin is an executable reading a Request as json from its stdin and
+// writing the files to generate, a json list of File, to its stdout.
+type Plugin struct {
+	Name    string
+	Command string
+	Args    []string
+	Options map[string]string
+}
+
+type Request struct {
+	Version string `json:"version"`
+	Name    string `json:"name"`
+	Engine  string `json:"engine"`
+	// ProjectPackageName is the module path of the project
+	ProjectPackageName string            `json:"project_package_name"`
+	StorePackageDir    string            `json:"store_package_dir"`
+	StorePackageName   string            `json:"store_package_name"`
+	ModelPackageDir    string            `json:"model_package_dir"`
+	Options            map[string]string `json:"options"`
+	PluginOptions      map[string]string `json:"plugin_options,omitempty"`
+	Tables             []Table           `json:"tables"`
+	Queries            []Query           `json:"queries"`
+}
+
+type Table struct {
+	introspect.Table
+	Fields []Field `json:"fields"`
+}
+
+type Query struct {
+	introspect.Query
+	Fields []Field `json:"fields"`
+	Params []Field `json:"params"`
+}
+
+// Field is a column with the Go type it is generated as.
+type Field struct {
+	Name       string `json:"name"`
+	ColumnName string `json:"column_name"`
+	DbType     string `json:"db_type"`
+	GoType     string `json:"go_type"`
+	Import     string `json:"import,omitempty"`
+	IsPointer  bool   `json:"is_pointer"`
+}
+
+// File is written to Path, relative to the project directory.
+type File struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
+
+// NewTable resolves the Go types of the columns of a table like the models
+// do.
+func NewTable(
+	translate types.Translate,
+	storePackageDir string,
+	storePackageName string,
+	table introspect.Table,
+) (Table, error) {
+	translate = types.ForTable(translate, table.SchemaName, table.TableName)
+
+	fields, err := newFields(translate, storePackageDir, storePackageName, table.Columns)
+
+	if err != nil {
+		return Table{}, err
+	}
+
+	return Table{Table: table, Fields: fields}, nil
+}
+
+// NewQuery resolves the Go types of the columns and parameters of a query
+// like the query models do.
+func NewQuery(
+	translate types.Translate,
+	storePackageDir string,
+	storePackageName string,
+	query introspect.Query,
+) (Query, error) {
+	fields, err := newFields(translate, storePackageDir, storePackageName, query.Columns)
+
+	if err != nil {
+		return Query{}, err
+	}
+
+	params, err := newFields(translate, storePackageDir, storePackageName, query.Params)
+
+	if err != nil {
+		return Query{}, err
+	}
+
+	return Query{Query: query, Fields: fields, Params: params}, nil
+}
+
+func newFields(
+	translate types.Translate,
+	storePackageDir string,
+	storePackageName string,
+	columns introspect.Columns,
+) ([]Field, error) {
+	fields := make([]Field, len(columns))
+
+	for idx, column := range columns {
+		f, err := types.NewField(column, translate, storePackageDir, storePackageName)
+
+		if err != nil {
+			return nil, err
+		}
+
+		fields[idx] = Field{
+			Name:       f.Name,
+			ColumnName: column.ColumnName,
+			DbType:     f.Type.DbType,
+			GoType:     f.Type.GoType,
+			Import:     f.Type.Import,
+			IsPointer:  f.Type.IsPointer,
+		}
+	}
+
+	return fields, nil
+}
+
+// Run executes the plugin in the project directory, a relative command with
+// a path is relative to it too.
+func (p Plugin) Run(projectDir string, request Request) ([]File, error) {
+	slog.Debug("running plugin", "plugin", p.Name, "command", p.Command)
+
+	request.Version = Version
+
+	request.PluginOptions = p.Options
+
+	input, err := json.Marshal(request)
+
+	if err != nil {
+		return nil, errorx.InternalError.Wrap(err, "unable to marshal request of plugin %s", p.Name)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.Command(p.Command, p.Args...)
+
+	cmd.Dir = projectDir
+
+	cmd.Stdin = bytes.NewReader(input)
+
+	cmd.Stdout = &stdout
+
+	cmd.Stderr = &stderr
+
+	err = cmd.Run()
+
+	if err != nil {
+		return nil, errorx.InternalError.Wrap(
+			err,
+			"plugin %s failed: %s",
+			p.Name,
+			strings.TrimSpace(stderr.String()),
+		)
+	}
+
+	files := make([]File, 0)
+
+	err = json.Unmarshal(stdout.Bytes(), &files)
+
+	if err != nil {
+		return nil, errorx.IllegalFormat.Wrap(err, "unable to read the files of plugin %s", p.Name)
+	}
+
+	slog.Debug("ran plugin", "plugin", p.Name, "files", len(files))
+
+	return files, nil
+}
@@ -0,0 +1,169 @@
+package plugin
+
+import (
+	"encoding/json"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/mvoorberg/sqlxgen/internal/generate/types"
+	"github.com/mvoorberg/sqlxgen/internal/introspect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlugin_Run(t *testing.T) {
+	t.Parallel()
+
+	command := buildPlugin(t)
+
+	ft := types.NewFakeTranslate("", "")
+
+	table, err := NewTable(
+		ft,
+		"github.com/john-doe/gen/store",
+		"store",
+		introspect.Table{
+			SchemaName: "public",
+			TableName:  "actors",
+			Columns: introspect.Columns{
+				{ColumnName: "id", Type: "int8", PkOrdinalPosition: 1},
+				{ColumnName: "name", Type: "text"},
+			},
+		},
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	query, err := NewQuery(
+		ft,
+		"github.com/john-doe/gen/store",
+		"store",
+		introspect.Query{
+			Filename: "get-actor.sql",
+			Columns:  introspect.Columns{{ColumnName: "released_on", Type: "date"}},
+			Params:   introspect.Columns{{ColumnName: "id", Type: "int8"}},
+		},
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	request := Request{
+		Name:    "test",
+		Engine:  "postgres",
+		Tables:  []Table{table},
+		Queries: []Query{query},
+	}
+
+	testCases := []struct {
+		name    string
+		options map[string]string
+		want    []File
+		err     string
+	}{
+		{
+			name:    "files",
+			options: map[string]string{"dir": "gen/plugin"},
+			want: []File{
+				{Path: "gen/plugin/actors.txt", Content: "v1 Id *int\nv1 Name *string\n"},
+				{Path: "gen/plugin/get-actor.sql.txt", Content: "v1 Id *int\nv1 ReleasedOn *time.Time\n"},
+			},
+		},
+		{
+			name:    "failure",
+			options: map[string]string{"fail": "no luck"},
+			err:     "plugin list failed: no luck",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := Plugin{Name: "list", Command: command, Options: testCase.options}
+
+			got, err := p.Run(t.TempDir(), request)
+
+			if testCase.err != "" {
+				assert.ErrorContains(t, err, testCase.err)
+
+				return
+			}
+
+			assert.Nil(t, err)
+
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
+func TestNewTable(t *testing.T) {
+	t.Parallel()
+
+	table, err := NewTable(
+		types.NewFakeTranslate("", ""),
+		"github.com/john-doe/gen/store",
+		"store",
+		introspect.Table{
+			SchemaName: "public",
+			TableName:  "movies",
+			Columns:    introspect.Columns{{ColumnName: "release_date", Type: "date", Nullable: true}},
+		},
+	)
+
+	assert.Nil(t, err)
+
+	got, err := json.Marshal(table)
+
+	assert.Nil(t, err)
+
+	want := `{
+		"schema_name": "public",
+		"table_name": "movies",
+		"columns": [
+			{
+				"column_name": "release_date",
+				"type": "date",
+				"type_id": "",
+				"is_array": false,
+				"is_sequence": false,
+				"nullable": true,
+				"generated": false,
+				"pk_name": "",
+				"pk_ordinal_position": 0,
+				"json_type": ""
+			}
+		],
+		"foreign_keys": null,
+		"unique_keys": null,
+		"fields": [
+			{
+				"name": "ReleaseDate",
+				"column_name": "release_date",
+				"db_type": "date",
+				"go_type": "*time.Time",
+				"import": "time",
+				"is_pointer": true
+			}
+		]
+	}`
+
+	assert.JSONEq(t, want, string(got))
+}
+
+func buildPlugin(t *testing.T) string {
+	command := filepath.Join(t.TempDir(), "plugin")
+
+	out, err := exec.Command("go", "build", "-o", command, "./testdata/plugin").CombinedOutput()
+
+	if err != nil {
+		t.Fatalf("failed to build plugin: %v: %s", err, out)
+	}
+
+	return command
+}
@@ -0,0 +1,85 @@
+// Command plugin lists the fields of every table and query, it is built by
+// the tests of the plugin package.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+type field struct {
+	Name   string `json:"name"`
+	GoType string `json:"go_type"`
+}
+
+type request struct {
+	Version       string            `json:"version"`
+	Name          string            `json:"name"`
+	PluginOptions map[string]string `json:"plugin_options"`
+	Tables        []struct {
+		TableName string  `json:"table_name"`
+		Fields    []field `json:"fields"`
+	} `json:"tables"`
+	Queries []struct {
+		Filename string  `json:"filename"`
+		Fields   []field `json:"fields"`
+		Params   []field `json:"params"`
+	} `json:"queries"`
+}
+
+type file struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
+
+func main() {
+	var req request
+
+	err := json.NewDecoder(os.Stdin).Decode(&req)
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+
+		os.Exit(1)
+	}
+
+	if req.PluginOptions["fail"] != "" {
+		fmt.Fprintln(os.Stderr, req.PluginOptions["fail"])
+
+		os.Exit(1)
+	}
+
+	dir := req.PluginOptions["dir"]
+
+	files := make([]file, 0)
+
+	for _, t := range req.Tables {
+		files = append(files, file{Path: dir + "/" + t.TableName + ".txt", Content: list(req.Version, t.Fields)})
+	}
+
+	for _, q := range req.Queries {
+		content := list(req.Version, q.Params) + list(req.Version, q.Fields)
+
+		files = append(files, file{Path: dir + "/" + q.Filename + ".txt", Content: content})
+	}
+
+	err = json.NewEncoder(os.Stdout).Encode(files)
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+
+		os.Exit(1)
+	}
+}
+
+func list(version string, fields []field) string {
+	var b strings.Builder
+
+	for _, f := range fields {
+		fmt.Fprintf(&b, "v%s %s %s\n", version, f.Name, f.GoType)
+	}
+
+	return b.String()
+}
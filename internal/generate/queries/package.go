@@ -3,6 +3,7 @@ package queries
 import (
 	"encoding/json"
 	"log/slog"
+	"path"
 
 	"github.com/mvoorberg/sqlxgen/internal/generate/types"
 	"github.com/mvoorberg/sqlxgen/internal/introspect"
@@ -14,14 +15,21 @@ type Package struct {
 	StorePackageDir  string
 	StorePackageName string
 	Queries          []queryModel
+	Options          map[string]string
+	// ExtraTemplates are rendered once per query file
+	ExtraTemplates []types.ExtraTemplate
 }
 
 func (p *Package) Generate() error {
-	for _, query := range p.Queries {
-		err := query.generate(p.QueryTemplate)
+	for _, file := range p.files() {
+		err := generateQueryFile(p.QueryTemplate, file, p.Options)
+
+		if err == nil {
+			err = p.generateExtraFiles(file)
+		}
 
 		if err != nil {
-			queryJson, err2 := json.Marshal(query)
+			queryJson, err2 := json.Marshal(file)
 
 			if err2 != nil {
 				return err
@@ -36,6 +44,43 @@ func (p *Package) Generate() error {
 	return nil
 }
 
+func (p *Package) generateExtraFiles(file []queryModel) error {
+	for _, extra := range p.ExtraTemplates {
+		err := generateExtraFile(extra, file, p.Options)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// files groups the queries by the sql file they were read from, in order.
+func (p *Package) files() [][]queryModel {
+	files := make([][]queryModel, 0)
+
+	index := make(map[string]int)
+
+	for _, query := range p.Queries {
+		key := path.Join(query.GenDir, query.Filename)
+
+		idx, ok := index[key]
+
+		if !ok {
+			idx = len(files)
+
+			index[key] = idx
+
+			files = append(files, make([]queryModel, 0))
+		}
+
+		files[idx] = append(files[idx], query)
+	}
+
+	return files
+}
+
 func NewPackage(
 	writerCreator writer.Creator,
 	translate types.Translate,
@@ -7,6 +7,9 @@ import (
 	"go/format"
 	"log/slog"
 	"path"
+	"slices"
+	"strconv"
+	"strings"
 	"text/template"
 
 	mapset "github.com/deckarep/golang-set"
@@ -29,25 +32,47 @@ type queryModel struct {
 	Fields           []types.Field  `json:"fields"`
 	Params           []types.Field  `json:"params"`
 	GenDir           string         `json:"-"`
+	Command          string         `json:"command,omitempty"`
+	Sql              string         `json:"sql,omitempty"`
+	JsonStructs      []jsonStruct   `json:"json_structs,omitempty"`
+}
+
+// jsonStruct is generated for a json column annotated with a Go type to
+// generate, its fields are the keys of the json object of the column.
+type jsonStruct struct {
+	Name   string      `json:"name"`
+	Column string      `json:"column"`
+	Fields []jsonField `json:"fields"`
+}
+
+type jsonField struct {
+	Name     string `json:"name"`
+	JsonName string `json:"json_name"`
+}
+
+// ReturnsRows reports whether the query has a result type, :exec and
+// :execrows queries only have args.
+func (qm queryModel) ReturnsRows() bool {
+	return introspect.ReturnsRows(qm.Command)
 }
 
 func (qm *queryModel) getImports() []string {
 	uniqueImports := mapset.NewSet()
 
 	for _, f := range qm.Fields {
-		if f.Type.Import == "" {
-			continue
+		for _, imp := range f.Type.Imports() {
+			uniqueImports.Add(imp)
 		}
-
-		uniqueImports.Add(f.Type.Import)
 	}
 
 	for _, f := range qm.Params {
-		if f.Type.Import == "" {
-			continue
+		for _, imp := range f.Type.Imports() {
+			uniqueImports.Add(imp)
 		}
+	}
 
-		uniqueImports.Add(f.Type.Import)
+	if qm.Command == "exec" {
+		uniqueImports.Add("database/sql")
 	}
 
 	importSlice := uniqueImports.ToSlice()
@@ -65,38 +90,31 @@ func (qm *queryModel) getImports() []string {
 }
 
 func (qm *queryModel) generate(queryTemplate string) error {
-	slog.Debug("generating query", "query", qm.Filename)
+	return generateQueryFile(queryTemplate, []queryModel{*qm}, nil)
+}
 
-	helpers := template.FuncMap{
-		"ToJson": func(v interface{}) string {
-			b, _ := json.MarshalIndent(v, "", " ")
+// generateQueryFile writes the queries read from one sql file to a single
+// generated file next to it.
+func generateQueryFile(queryTemplate string, qms []queryModel, options map[string]string) error {
+	qm := qms[0]
 
-			return string(b)
-		},
-	}
+	slog.Debug("generating query", "query", qm.Filename)
 
-	tmpl, err := template.New("query").Funcs(helpers).Parse(queryTemplate)
+	tmpl, err := template.New("query").Funcs(queryHelpers()).Parse(queryTemplate)
 
 	if err != nil {
 		return errorx.IllegalFormat.Wrap(err, "unable to parse model template")
 	}
 
-	packageNameBaseDir := path.Base(qm.GenDir)
+	data, err := queryTemplateData(qms, options)
 
-	packageName, err := casing.SnakeCase(packageNameBaseDir)
-
-	imports := qm.getImports()
+	if err != nil {
+		return err
+	}
 
 	var queryFileBuffer bytes.Buffer
 
-	err = tmpl.Execute(
-		&queryFileBuffer,
-		map[string]interface{}{
-			"PackageName": packageName,
-			"Imports":     imports,
-			"Query":       qm,
-		},
-	)
+	err = tmpl.Execute(&queryFileBuffer, data)
 
 	if err != nil {
 		return errorx.InternalError.Wrap(err, "unable to execute model template")
@@ -108,31 +126,174 @@ func (qm *queryModel) generate(queryTemplate string) error {
 		return err
 	}
 
-	qfn, _ := utils.SplitFilename(qm.Filename)
+	queryFileName := utils.FilenameWithGen(data["FileName"].(string) + ".go")
 
-	qfnSnakeName, err := casing.SnakeCase(qfn)
+	queryFilePath := path.Join(qm.GenDir, queryFileName)
+
+	pen := qm.WriterCreator(queryFilePath, string(formatted))
+
+	err = pen.Write()
+
+	if err != nil {
+		return errorx.InternalError.Wrap(err, "unable to write generated query file")
+	}
+
+	slog.Debug("generated query", "query", qm.Filename)
+
+	return nil
+}
+
+// generateExtraFile renders an extra template of the queries read from one
+// sql file next to it.
+func generateExtraFile(extra types.ExtraTemplate, qms []queryModel, options map[string]string) error {
+	qm := qms[0]
+
+	data, err := queryTemplateData(qms, options)
 
 	if err != nil {
 		return err
 	}
 
-	queryFileName := utils.FilenameWithGen(qfnSnakeName + ".go")
+	fileName, content, err := extra.Render(queryHelpers(), data)
 
-	queryFilePath := path.Join(qm.GenDir, queryFileName)
+	if err != nil {
+		return errorx.Decorate(err, "unable to render %s for query %s", extra.Name, qm.Filename)
+	}
 
-	pen := qm.WriterCreator(queryFilePath, string(formatted))
+	pen := qm.WriterCreator(path.Join(qm.GenDir, fileName), content)
 
 	err = pen.Write()
 
 	if err != nil {
-		return errorx.InternalError.Wrap(err, "unable to write generated query file")
+		return errorx.InternalError.Wrap(err, "unable to write %s file", fileName)
 	}
 
-	slog.Debug("generated query", "query", qm.Filename)
-
 	return nil
 }
 
+// queryTemplateData holds the first query of the file as Query, FileName is
+// the snake cased name of the sql file.
+func queryTemplateData(qms []queryModel, options map[string]string) (map[string]interface{}, error) {
+	qm := qms[0]
+
+	packageNameBaseDir := path.Base(qm.GenDir)
+
+	packageName, err := casing.SnakeCase(packageNameBaseDir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	qfn, _ := utils.SplitFilename(qm.Filename)
+
+	qfnSnakeName, err := casing.SnakeCase(qfn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"PackageName": packageName,
+		"FileName":    qfnSnakeName,
+		"Imports":     fileImports(qms),
+		"JsonStructs": fileJsonStructs(qms),
+		"Query":       &qm,
+		"Queries":     qms,
+		"Options":     options,
+	}
+
+	return data, nil
+}
+
+func queryHelpers() template.FuncMap {
+	return template.FuncMap{
+		"ToJson": func(v interface{}) string {
+			b, _ := json.MarshalIndent(v, "", " ")
+
+			return string(b)
+		},
+		"GoString":   goString,
+		"ImportSpec": types.ImportSpec,
+	}
+}
+
+// fileImports merges the imports of the queries sharing a generated file,
+// the store package stays last.
+func fileImports(qms []queryModel) []string {
+	imports := make([]string, 0)
+
+	for _, qm := range qms {
+		for _, imp := range qm.getImports() {
+			if imp != qm.StorePackageDir && !slices.Contains(imports, imp) {
+				imports = append(imports, imp)
+			}
+		}
+	}
+
+	return append(imports, qms[0].StorePackageDir)
+}
+
+// fileJsonStructs merges the json structs of the queries sharing a generated
+// file, a struct generated for several columns is only kept once.
+func fileJsonStructs(qms []queryModel) []jsonStruct {
+	structs := make([]jsonStruct, 0)
+
+	for _, qm := range qms {
+		for _, js := range qm.JsonStructs {
+			if !slices.ContainsFunc(structs, func(s jsonStruct) bool { return s.Name == js.Name }) {
+				structs = append(structs, js)
+			}
+		}
+	}
+
+	return structs
+}
+
+// newJsonStructs lists the structs to generate for the json columns of a
+// query.
+func newJsonStructs(fields []types.Field) ([]jsonStruct, error) {
+	structs := make([]jsonStruct, 0)
+
+	for _, f := range fields {
+		if len(f.Column.JsonKeys) == 0 {
+			continue
+		}
+
+		js := jsonStruct{
+			Name:   strings.TrimLeft(f.Column.JsonType, "*[]"),
+			Column: f.Column.ColumnName,
+			Fields: make([]jsonField, 0),
+		}
+
+		for _, key := range f.Column.JsonKeys {
+			name, err := casing.PascalCase(key)
+
+			if err != nil {
+				return nil, errorx.InternalError.Wrap(err, "failed to convert json key to pascal case")
+			}
+
+			if slices.ContainsFunc(js.Fields, func(jf jsonField) bool { return jf.Name == name }) {
+				continue
+			}
+
+			js.Fields = append(js.Fields, jsonField{Name: name, JsonName: key})
+		}
+
+		structs = append(structs, js)
+	}
+
+	return structs, nil
+}
+
+// goString quotes sql as a raw string literal unless it holds a backtick.
+func goString(sql string) string {
+	if strings.Contains(sql, "`") {
+		return strconv.Quote(sql)
+	}
+
+	return "`" + sql + "`"
+}
+
 func newQueryModel(
 	writerCreator writer.Creator,
 	translate types.Translate,
@@ -143,6 +304,11 @@ func newQueryModel(
 ) (queryModel, error) {
 	filename, _ := utils.SplitFilename(query.Filename)
 
+	// named queries carry their own names, the others are named after the file
+	if query.Sql != "" {
+		filename = query.QueryName
+	}
+
 	pascalName, err := casing.PascalCase(filename)
 
 	if err != nil {
@@ -179,6 +345,12 @@ func newQueryModel(
 		params[i] = p
 	}
 
+	jsonStructs, err := newJsonStructs(fields)
+
+	if err != nil {
+		return queryModel{}, err
+	}
+
 	genDir := path.Join(projectDir, query.SourceDir)
 
 	qm := queryModel{
@@ -191,6 +363,9 @@ func newQueryModel(
 		Fields:           fields,
 		Params:           params,
 		GenDir:           genDir,
+		Command:          query.Command,
+		Sql:              query.Sql,
+		JsonStructs:      jsonStructs,
 	}
 
 	return qm, nil
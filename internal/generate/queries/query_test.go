@@ -208,6 +208,41 @@ var content = `+"`"+`{
 	}
 }
 
+func TestNewJsonStructs(t *testing.T) {
+	t.Parallel()
+
+	fields := []types.Field{
+		{Column: introspect.Column{ColumnName: "id", Type: "int8"}},
+		{
+			Column: introspect.Column{
+				ColumnName: "movies",
+				Type:       "jsonb",
+				JsonType:   "[]MovieSummary",
+				JsonKeys:   []string{"id", "release_date", "releaseDate"},
+			},
+		},
+	}
+
+	got, err := newJsonStructs(fields)
+
+	assert.Nil(t, err)
+
+	want := []jsonStruct{
+		{
+			Name:   "MovieSummary",
+			Column: "movies",
+			Fields: []jsonField{
+				{Name: "Id", JsonName: "id"},
+				{Name: "ReleaseDate", JsonName: "release_date"},
+			},
+		},
+	}
+
+	assert.Equal(t, want, got)
+
+	assert.Equal(t, want, fileJsonStructs([]queryModel{{JsonStructs: got}, {JsonStructs: got}}))
+}
+
 //go:embed fixtures/list-actor-query.json
 var listActorQueryJson string
 
@@ -0,0 +1,97 @@
+package sqlite
+
+import (
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+
+	"github.com/mvoorberg/sqlxgen/internal/generate/types"
+	"github.com/mvoorberg/sqlxgen/internal/introspect"
+)
+
+// ref: https://www.sqlite.org/datatype3.html#determination_of_column_affinity
+func infer(storePackageDir string, column introspect.Column) (types.GoType, error) {
+	goType := types.GoType{
+		DbType:    column.Type,
+		GoType:    "interface{}",
+		IsPointer: true,
+	}
+
+	columnType := strings.ToLower(column.Type)
+
+	// names with a well-known meaning, which sqlite would otherwise store
+	// with a numeric affinity
+	switch columnType {
+	case "boolean", "bool":
+		goType.GoType = "*bool"
+
+		return goType, nil
+
+	case "date", "datetime", "timestamp", "time":
+		goType.Import = "time"
+
+		goType.GoType = "*time.Time"
+
+		return goType, nil
+
+	case "json", "jsonb":
+		goType.GoType = "*json.RawMessage"
+
+		goType.Import = "encoding/json"
+
+		_, storePkg := filepath.Split(storePackageDir)
+
+		if column.JsonType == "array" {
+			goType.GoType = fmt.Sprintf("*%s.JsonArray", storePkg)
+
+			goType.Import = storePackageDir
+		}
+
+		if column.JsonType == "object" {
+			goType.GoType = fmt.Sprintf("*%s.JsonObject", storePkg)
+
+			goType.Import = storePackageDir
+		}
+
+		return goType, nil
+
+	case "uuid":
+		goType.GoType = "*string"
+
+		return goType, nil
+
+	case "":
+		// expressions and untyped columns carry no affinity
+		return goType, nil
+	}
+
+	switch {
+	case strings.Contains(columnType, "int"):
+		goType.GoType = "*int64"
+
+	case strings.Contains(columnType, "char"),
+		strings.Contains(columnType, "clob"),
+		strings.Contains(columnType, "text"):
+		goType.GoType = "*string"
+
+	case strings.Contains(columnType, "blob"):
+		goType.GoType = "[]byte"
+
+		goType.IsPointer = false
+
+	case strings.Contains(columnType, "real"),
+		strings.Contains(columnType, "floa"),
+		strings.Contains(columnType, "doub"):
+		goType.GoType = "*float64"
+
+	case strings.Contains(columnType, "num"),
+		strings.Contains(columnType, "dec"):
+		goType.GoType = "*float64"
+
+	default:
+		slog.Warn("Unknown column type, using interface{} as default", column.ColumnName, column.Type)
+	}
+
+	return goType, nil
+}
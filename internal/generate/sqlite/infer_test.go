@@ -0,0 +1,244 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/mvoorberg/sqlxgen/internal/generate/types"
+	"github.com/mvoorberg/sqlxgen/internal/introspect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInfer(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		column introspect.Column
+		want   types.GoType
+		err    error
+	}{
+		{
+			name: "integer",
+			column: introspect.Column{
+				ColumnName: "c1",
+				Type:       "integer",
+			},
+			want: types.GoType{
+				DbType:    "integer",
+				GoType:    "*int64",
+				IsPointer: true,
+			},
+			err: nil,
+		},
+		{
+			name: "bigint",
+			column: introspect.Column{
+				ColumnName: "c1",
+				Type:       "bigint",
+			},
+			want: types.GoType{
+				DbType:    "bigint",
+				GoType:    "*int64",
+				IsPointer: true,
+			},
+			err: nil,
+		},
+		{
+			name: "varchar",
+			column: introspect.Column{
+				ColumnName: "c1",
+				Type:       "varchar",
+			},
+			want: types.GoType{
+				DbType:    "varchar",
+				GoType:    "*string",
+				IsPointer: true,
+			},
+			err: nil,
+		},
+		{
+			name: "text",
+			column: introspect.Column{
+				ColumnName: "c1",
+				Type:       "text",
+			},
+			want: types.GoType{
+				DbType:    "text",
+				GoType:    "*string",
+				IsPointer: true,
+			},
+			err: nil,
+		},
+		{
+			name: "clob",
+			column: introspect.Column{
+				ColumnName: "c1",
+				Type:       "clob",
+			},
+			want: types.GoType{
+				DbType:    "clob",
+				GoType:    "*string",
+				IsPointer: true,
+			},
+			err: nil,
+		},
+		{
+			name: "real",
+			column: introspect.Column{
+				ColumnName: "c1",
+				Type:       "real",
+			},
+			want: types.GoType{
+				DbType:    "real",
+				GoType:    "*float64",
+				IsPointer: true,
+			},
+			err: nil,
+		},
+		{
+			name: "double",
+			column: introspect.Column{
+				ColumnName: "c1",
+				Type:       "double",
+			},
+			want: types.GoType{
+				DbType:    "double",
+				GoType:    "*float64",
+				IsPointer: true,
+			},
+			err: nil,
+		},
+		{
+			name: "numeric",
+			column: introspect.Column{
+				ColumnName: "c1",
+				Type:       "numeric",
+			},
+			want: types.GoType{
+				DbType:    "numeric",
+				GoType:    "*float64",
+				IsPointer: true,
+			},
+			err: nil,
+		},
+		{
+			name: "decimal",
+			column: introspect.Column{
+				ColumnName: "c1",
+				Type:       "decimal",
+			},
+			want: types.GoType{
+				DbType:    "decimal",
+				GoType:    "*float64",
+				IsPointer: true,
+			},
+			err: nil,
+		},
+		{
+			name: "boolean",
+			column: introspect.Column{
+				ColumnName: "c1",
+				Type:       "boolean",
+			},
+			want: types.GoType{
+				DbType:    "boolean",
+				GoType:    "*bool",
+				IsPointer: true,
+			},
+			err: nil,
+		},
+		{
+			name: "date",
+			column: introspect.Column{
+				ColumnName: "c1",
+				Type:       "date",
+			},
+			want: types.GoType{
+				DbType:    "date",
+				GoType:    "*time.Time",
+				IsPointer: true,
+				Import:    "time",
+			},
+			err: nil,
+		},
+		{
+			name: "datetime",
+			column: introspect.Column{
+				ColumnName: "c1",
+				Type:       "datetime",
+			},
+			want: types.GoType{
+				DbType:    "datetime",
+				GoType:    "*time.Time",
+				IsPointer: true,
+				Import:    "time",
+			},
+			err: nil,
+		},
+		{
+			name: "json",
+			column: introspect.Column{
+				ColumnName: "c1",
+				Type:       "json",
+			},
+			want: types.GoType{
+				DbType:    "json",
+				GoType:    "*json.RawMessage",
+				IsPointer: true,
+				Import:    "encoding/json",
+			},
+			err: nil,
+		},
+		{
+			name: "blob",
+			column: introspect.Column{
+				ColumnName: "c1",
+				Type:       "blob",
+			},
+			want: types.GoType{
+				DbType:    "blob",
+				GoType:    "[]byte",
+				IsPointer: false,
+			},
+			err: nil,
+		},
+		{
+			name: "untyped",
+			column: introspect.Column{
+				ColumnName: "c1",
+				Type:       "",
+			},
+			want: types.GoType{
+				DbType:    "",
+				GoType:    "interface{}",
+				IsPointer: true,
+			},
+			err: nil,
+		},
+		{
+			name: "json array",
+			column: introspect.Column{
+				ColumnName: "c1",
+				Type:       "json",
+				JsonType:   "array",
+			},
+			want: types.GoType{
+				DbType:    "json",
+				GoType:    "*store.JsonArray",
+				IsPointer: true,
+				Import:    "github.com/john-doe/gen/store",
+			},
+			err: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got, err := infer("github.com/john-doe/gen/store", testCase.column)
+
+			assert.Equal(t, testCase.err, err)
+
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
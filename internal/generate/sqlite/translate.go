@@ -0,0 +1,36 @@
+package sqlite
+
+import (
+	_ "embed"
+
+	"github.com/mvoorberg/sqlxgen/internal/generate/types"
+	"github.com/mvoorberg/sqlxgen/internal/introspect"
+)
+
+type Sqlite struct{}
+
+func (sqlite Sqlite) Infer(
+	storePackageDir string,
+	_ string,
+	column introspect.Column,
+) (types.GoType, error) {
+	return infer(storePackageDir, column)
+}
+
+func (sqlite Sqlite) ModelTemplate() string {
+	return modelTemplate
+}
+
+func (sqlite Sqlite) QueryTemplate() string {
+	return queryTemplate
+}
+
+//go:embed model.go.tmpl
+var modelTemplate string
+
+//go:embed query.go.tmpl
+var queryTemplate string
+
+func NewTranslate() types.Translate {
+	return Sqlite{}
+}
@@ -0,0 +1,127 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/mvoorberg/sqlxgen/internal/generate/types"
+	"github.com/mvoorberg/sqlxgen/internal/introspect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSqlite_ModelTemplate(t *testing.T) {
+	translate := NewTranslate()
+
+	assert.Equal(t, modelTemplate, translate.ModelTemplate())
+}
+
+func TestSqlite_QueryTemplate(t *testing.T) {
+	translate := NewTranslate()
+
+	assert.Equal(t, queryTemplate, translate.QueryTemplate())
+}
+
+func TestSqlite_Infer(t *testing.T) {
+	t.Parallel()
+
+	translate := NewTranslate()
+
+	testCases := []struct {
+		name   string
+		column introspect.Column
+		want   types.GoType
+		err    error
+	}{
+		{
+			name: "integer",
+			column: introspect.Column{
+				ColumnName: "c1",
+				Type:       "integer",
+			},
+			want: types.GoType{
+				DbType:    "integer",
+				GoType:    "*int64",
+				IsPointer: true,
+			},
+			err: nil,
+		},
+		{
+			name: "bigint",
+			column: introspect.Column{
+				ColumnName: "c1",
+				Type:       "bigint",
+			},
+			want: types.GoType{
+				DbType:    "bigint",
+				GoType:    "*int64",
+				IsPointer: true,
+			},
+			err: nil,
+		},
+		{
+			name: "varchar",
+			column: introspect.Column{
+				ColumnName: "c1",
+				Type:       "varchar",
+			},
+			want: types.GoType{
+				DbType:    "varchar",
+				GoType:    "*string",
+				IsPointer: true,
+			},
+			err: nil,
+		},
+		{
+			name: "text",
+			column: introspect.Column{
+				ColumnName: "c1",
+				Type:       "text",
+			},
+			want: types.GoType{
+				DbType:    "text",
+				GoType:    "*string",
+				IsPointer: true,
+			},
+			err: nil,
+		},
+		{
+			name: "clob",
+			column: introspect.Column{
+				ColumnName: "c1",
+				Type:       "clob",
+			},
+			want: types.GoType{
+				DbType:    "clob",
+				GoType:    "*string",
+				IsPointer: true,
+			},
+			err: nil,
+		},
+		{
+			name: "real",
+			column: introspect.Column{
+				ColumnName: "c1",
+				Type:       "real",
+			},
+			want: types.GoType{
+				DbType:    "real",
+				GoType:    "*float64",
+				IsPointer: true,
+			},
+			err: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got, err := translate.Infer(
+				"github.com/john-doe/gen/store",
+				"store",
+				testCase.column,
+			)
+
+			assert.Equal(t, testCase.err, err)
+
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package store
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/jinzhu/inflection"
+	"github.com/joomcode/errorx"
+	"github.com/mvoorberg/sqlxgen/internal/introspect"
+	"github.com/mvoorberg/sqlxgen/internal/utils/casing"
+)
+
+type Enum struct {
+	Name   string      `json:"name"`
+	DbName string      `json:"db_name"`
+	Values []EnumValue `json:"values"`
+}
+
+type EnumValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type enumRegistry struct {
+	enums    []Enum
+	byKey    map[string]int
+	byName   map[string]int
+	reserved map[string]bool
+}
+
+// ResolveEnums names every enum column found in the tables and queries and
+// returns the enum types to be generated in the store package.
+//
+// Postgres enums are named after their type, MySQL enums after the table (or
+// query) and column they are declared on. Query columns reuse a table enum
+// when both share the same labels. Enums aren't named like the identifiers of
+// the store package, rendered from customTemplate when set, like
+// Package.Template.
+func ResolveEnums(
+	tables []introspect.Table,
+	queries []introspect.Query,
+	customTemplate string,
+) ([]introspect.Table, []introspect.Query, []Enum, error) {
+	registry := enumRegistry{
+		enums:    make([]Enum, 0),
+		byKey:    make(map[string]int),
+		byName:   make(map[string]int),
+		reserved: storeIdentifiers(storeContent(customTemplate), copyTemplate),
+	}
+
+	resolvedTables := make([]introspect.Table, len(tables))
+
+	for idx, table := range tables {
+		columns := slices.Clone(table.Columns)
+
+		for cIdx, column := range columns {
+			if len(column.EnumValues) == 0 {
+				continue
+			}
+
+			owner := inflection.Singular(table.TableName)
+
+			source := fmt.Sprintf("%s.%s", table.TableName, column.ColumnName)
+
+			name, err := registry.register(owner, source, column, false)
+
+			if err != nil {
+				msg := fmt.Sprintf("unable to resolve enum of %s.%s", table.TableName, column.ColumnName)
+
+				return nil, nil, nil, errorx.Decorate(err, msg)
+			}
+
+			columns[cIdx].EnumName = name
+		}
+
+		table.Columns = columns
+
+		resolvedTables[idx] = table
+	}
+
+	resolvedQueries := make([]introspect.Query, len(queries))
+
+	for idx, query := range queries {
+		columns := slices.Clone(query.Columns)
+
+		for cIdx, column := range columns {
+			if len(column.EnumValues) == 0 {
+				continue
+			}
+
+			source := fmt.Sprintf("%s.%s", query.QueryName, column.ColumnName)
+
+			name, err := registry.register(query.QueryName, source, column, true)
+
+			if err != nil {
+				msg := fmt.Sprintf("unable to resolve enum of %s.%s", query.Filename, column.ColumnName)
+
+				return nil, nil, nil, errorx.Decorate(err, msg)
+			}
+
+			columns[cIdx].EnumName = name
+		}
+
+		params := slices.Clone(query.Params)
+
+		for pIdx, param := range params {
+			enumIdx, ok := registry.byKey[param.Type]
+
+			if !ok || param.IsArray {
+				continue
+			}
+
+			enum := registry.enums[enumIdx]
+
+			params[pIdx].EnumName = enum.Name
+
+			params[pIdx].EnumValues = enumLabels(enum)
+		}
+
+		query.Columns = columns
+
+		query.Params = params
+
+		resolvedQueries[idx] = query
+	}
+
+	return resolvedTables, resolvedQueries, registry.enums, nil
+}
+
+func (r *enumRegistry) register(
+	owner string,
+	source string,
+	column introspect.Column,
+	reuse bool,
+) (string, error) {
+	if column.IsArray {
+		return "", nil
+	}
+
+	// mysql reports every enum column as type "enum", pg reports the enum type name
+	named := column.Type != "enum"
+
+	key := column.Type
+
+	baseName := column.Type
+
+	if !named {
+		key = source
+
+		baseName = fmt.Sprintf("%s_%s", owner, column.ColumnName)
+	}
+
+	if idx, ok := r.byKey[key]; ok {
+		return r.enums[idx].Name, nil
+	}
+
+	if reuse && !named {
+		for _, enum := range r.enums {
+			if slices.Equal(enumLabels(enum), column.EnumValues) {
+				return enum.Name, nil
+			}
+		}
+	}
+
+	name, err := casing.PascalCase(identifierWords(baseName))
+
+	if err != nil {
+		return "", err
+	}
+
+	if name == "" {
+		return "", errorx.IllegalArgument.New("unable to derive a type name for enum %s", baseName)
+	}
+
+	if r.hasName(name) {
+		slog.Warn("enum type name already taken, adding a suffix", "enum", name, "column", column.ColumnName)
+
+		suffix := 2
+
+		for r.hasName(fmt.Sprintf("%s%d", name, suffix)) {
+			suffix++
+		}
+
+		name = fmt.Sprintf("%s%d", name, suffix)
+	}
+
+	enum := Enum{
+		Name:   name,
+		DbName: key,
+		Values: enumValues(name, column.EnumValues, r.reserved),
+	}
+
+	r.enums = append(r.enums, enum)
+
+	r.byKey[key] = len(r.enums) - 1
+
+	r.byName[name] = len(r.enums) - 1
+
+	return name, nil
+}
+
+func (r *enumRegistry) hasName(name string) bool {
+	_, ok := r.byName[name]
+
+	return ok || r.reserved[name]
+}
+
+var identifierRe = regexp.MustCompile(`(?m)^(?:func|type|var|const)\s+([A-Z]\w*)`)
+
+// storeIdentifiers are the exported identifiers of the store package, an enum
+// type or value named like one of them would clash with it.
+func storeIdentifiers(templates ...string) map[string]bool {
+	names := make(map[string]bool)
+
+	for _, content := range templates {
+		for _, match := range identifierRe.FindAllStringSubmatch(content, -1) {
+			names[match[1]] = true
+		}
+	}
+
+	return names
+}
+
+func enumValues(enumName string, labels []string, reserved map[string]bool) []EnumValue {
+	values := make([]EnumValue, len(labels))
+
+	taken := make(map[string]bool)
+
+	for idx, label := range labels {
+		suffix, err := casing.PascalCase(identifierWords(label))
+
+		if err != nil || suffix == "" {
+			suffix = fmt.Sprintf("Value%d", idx)
+		}
+
+		name := enumName + suffix
+
+		if taken[name] || reserved[name] {
+			name = fmt.Sprintf("%s%d", name, idx)
+		}
+
+		taken[name] = true
+
+		values[idx] = EnumValue{Name: name, Value: label}
+	}
+
+	return values
+}
+
+func enumLabels(enum Enum) []string {
+	labels := make([]string, len(enum.Values))
+
+	for idx, value := range enum.Values {
+		labels[idx] = value.Value
+	}
+
+	return labels
+}
+
+// identifierWords replaces every character that can't appear in a Go
+// identifier by an underscore, so that casing can split on it.
+func identifierWords(s string) string {
+	return strings.Map(
+		func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return r
+			}
+
+			return '_'
+		},
+		s,
+	)
+}
@@ -0,0 +1,167 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/mvoorberg/sqlxgen/internal/introspect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveEnums(t *testing.T) {
+	t.Parallel()
+
+	tables := []introspect.Table{
+		{
+			SchemaName: "public",
+			TableName:  "movies",
+			Columns: introspect.Columns{
+				{ColumnName: "id", Type: "int8"},
+				{ColumnName: "rating", Type: "mpaa_rating", EnumValues: introspect.EnumValues{"G", "PG-13", "NC-17"}},
+				{ColumnName: "status", Type: "enum", EnumValues: introspect.EnumValues{"released", "in production"}},
+			},
+		},
+		{
+			SchemaName: "public",
+			TableName:  "series",
+			Columns: introspect.Columns{
+				{ColumnName: "rating", Type: "mpaa_rating", EnumValues: introspect.EnumValues{"G", "PG-13", "NC-17"}},
+			},
+		},
+	}
+
+	queries := []introspect.Query{
+		{
+			Filename:  "list-movies.sql",
+			QueryName: "ListMovies",
+			Columns: introspect.Columns{
+				{ColumnName: "status", Type: "enum", EnumValues: introspect.EnumValues{"released", "in production"}},
+				{ColumnName: "phase", Type: "enum", EnumValues: introspect.EnumValues{"alpha", "beta"}},
+			},
+			Params: introspect.Columns{
+				{ColumnName: "rating", Type: "mpaa_rating"},
+				{ColumnName: "title", Type: "text"},
+			},
+		},
+	}
+
+	gotTables, gotQueries, enums, err := ResolveEnums(tables, queries, "")
+
+	assert.Nil(t, err)
+
+	want := []Enum{
+		{
+			Name:   "MpaaRating",
+			DbName: "mpaa_rating",
+			Values: []EnumValue{
+				{Name: "MpaaRatingG", Value: "G"},
+				{Name: "MpaaRatingPG13", Value: "PG-13"},
+				{Name: "MpaaRatingNC17", Value: "NC-17"},
+			},
+		},
+		{
+			Name:   "MovieStatus",
+			DbName: "movies.status",
+			Values: []EnumValue{
+				{Name: "MovieStatusReleased", Value: "released"},
+				{Name: "MovieStatusInProduction", Value: "in production"},
+			},
+		},
+		{
+			Name:   "ListMoviesPhase",
+			DbName: "ListMovies.phase",
+			Values: []EnumValue{
+				{Name: "ListMoviesPhaseAlpha", Value: "alpha"},
+				{Name: "ListMoviesPhaseBeta", Value: "beta"},
+			},
+		},
+	}
+
+	assert.Equal(t, want, enums)
+
+	assert.Equal(t, "", gotTables[0].Columns[0].EnumName)
+	assert.Equal(t, "MpaaRating", gotTables[0].Columns[1].EnumName)
+	assert.Equal(t, "MovieStatus", gotTables[0].Columns[2].EnumName)
+	assert.Equal(t, "MpaaRating", gotTables[1].Columns[0].EnumName)
+
+	assert.Equal(t, "MovieStatus", gotQueries[0].Columns[0].EnumName)
+	assert.Equal(t, "ListMoviesPhase", gotQueries[0].Columns[1].EnumName)
+	assert.Equal(t, "MpaaRating", gotQueries[0].Params[0].EnumName)
+	assert.Equal(t, "", gotQueries[0].Params[1].EnumName)
+
+	// the inputs are left untouched
+	assert.Equal(t, "", tables[0].Columns[1].EnumName)
+}
+
+func TestResolveEnums_ReservedNames(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		template   string
+		column     introspect.Column
+		wantName   string
+		wantValues []string
+	}{
+		{
+			name:     "order",
+			column:   introspect.Column{ColumnName: "sort", Type: "order", EnumValues: introspect.EnumValues{"asc", "desc"}},
+			wantName: "Order2",
+			wantValues: []string{
+				"Order2Asc",
+				"Order2Desc",
+			},
+		},
+		{
+			name:       "json",
+			column:     introspect.Column{ColumnName: "format", Type: "json", EnumValues: introspect.EnumValues{"text"}},
+			wantName:   "Json2",
+			wantValues: []string{"Json2Text"},
+		},
+		{
+			name:       "value",
+			column:     introspect.Column{ColumnName: "mode", Type: "find", EnumValues: introspect.EnumValues{"many", "one"}},
+			wantName:   "Find",
+			wantValues: []string{"FindMany0", "FindOne1"},
+		},
+		{
+			name:       "custom template",
+			template:   "package {{ .PackageName }}\n\nfunc Lookup() {}\n\nvar LookupAll = 1\n",
+			column:     introspect.Column{ColumnName: "kind", Type: "lookup", EnumValues: introspect.EnumValues{"all", "one"}},
+			wantName:   "Lookup2",
+			wantValues: []string{"Lookup2All", "Lookup2One"},
+		},
+		{
+			name:       "custom template without the built-in identifiers",
+			template:   "package {{ .PackageName }}\n\nfunc Lookup() {}\n",
+			column:     introspect.Column{ColumnName: "sort", Type: "order", EnumValues: introspect.EnumValues{"asc", "desc"}},
+			wantName:   "Order",
+			wantValues: []string{"OrderAsc", "OrderDesc"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			tables := []introspect.Table{
+				{SchemaName: "public", TableName: "movies", Columns: introspect.Columns{testCase.column}},
+			}
+
+			_, _, enums, err := ResolveEnums(tables, nil, testCase.template)
+
+			assert.Nil(t, err)
+
+			assert.Equal(t, testCase.wantName, enums[0].Name)
+
+			names := make([]string, len(enums[0].Values))
+
+			for idx, value := range enums[0].Values {
+				names[idx] = value.Name
+			}
+
+			assert.Equal(t, testCase.wantValues, names)
+		})
+	}
+}
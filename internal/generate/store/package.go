@@ -5,7 +5,6 @@ import (
 	_ "embed"
 	"go/format"
 	"log/slog"
-	"os"
 	"path"
 	"path/filepath"
 	"text/template"
@@ -22,6 +21,11 @@ type Package struct {
 	PackageDir    string         `json:"package_dir"`
 	GenDir        string         `json:"gen_dir"`
 	Options       map[string]string
+	// Engine of the database, postgres also gets the COPY functions
+	Engine string `json:"engine"`
+	Enums  []Enum `json:"enums"`
+	// Template replaces the built-in store template when set
+	Template string `json:"-"`
 }
 
 func (p Package) Generate() error {
@@ -37,51 +41,81 @@ func (p Package) Generate() error {
 		},
 	}
 
-	tmpl, err := template.New(p.PackageName).Funcs(helpers).Parse(storeTemplate)
+	err := p.render("store.go", storeContent(p.Template), helpers)
 
 	if err != nil {
-		return errorx.IllegalFormat.Wrap(err, "unable to parse store template")
+		return err
 	}
 
-	var storeFileBuffer bytes.Buffer
+	if len(p.Enums) > 0 {
+		err = p.render("enums.go", enumsTemplate, helpers)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if p.Engine == "postgres" {
+		err = p.render("copy.go", copyTemplate, helpers)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	slog.Debug("generated store package")
+
+	return nil
+}
+
+// storeContent is the template of store.go, the custom template when set.
+func storeContent(customTemplate string) string {
+	if customTemplate != "" {
+		return customTemplate
+	}
+
+	return storeTemplate
+}
+
+func (p Package) render(fileName string, content string, helpers template.FuncMap) error {
+	tmpl, err := template.New(fileName).Funcs(helpers).Parse(content)
+
+	if err != nil {
+		return errorx.IllegalFormat.Wrap(err, "unable to parse %s template", fileName)
+	}
+
+	var fileBuffer bytes.Buffer
 
 	err = tmpl.Execute(
-		&storeFileBuffer,
+		&fileBuffer,
 		map[string]interface{}{
 			"PackageName": p.PackageName,
+			"Enums":       p.Enums,
+			"Options":     p.Options,
+			"Engine":      p.Engine,
 		},
 	)
 
 	if err != nil {
-		return errorx.InternalError.Wrap(err, "unable to execute store template")
-	}
-
-	formatted, err := format.Source(storeFileBuffer.Bytes())
-
-	if err != nil {
-		return errorx.InternalError.Wrap(err, "unable to format store template")
+		return errorx.InternalError.Wrap(err, "unable to execute %s template", fileName)
 	}
 
-	err = os.MkdirAll(p.GenDir, 0755)
+	formatted, err := format.Source(fileBuffer.Bytes())
 
 	if err != nil {
-		return errorx.InitializationFailed.Wrap(err, "unable to create directory for store")
+		return errorx.InternalError.Wrap(err, "unable to format %s template", fileName)
 	}
 
-	genFileName := utils.FilenameWithGen("store.go")
+	genFilePath := path.Join(p.GenDir, utils.FilenameWithGen(fileName))
 
-	storeFilePath := path.Join(p.GenDir, genFileName)
-
-	pen := p.WriterCreator(storeFilePath, string(formatted))
+	pen := p.WriterCreator(genFilePath, string(formatted))
 
 	err = pen.Write()
 
 	if err != nil {
-		return errorx.InternalError.Wrap(err, "unable to write store file")
+		return errorx.InternalError.Wrap(err, "unable to write %s file", fileName)
 	}
 
-	slog.Debug("generated store package")
-
 	return nil
 }
 
@@ -112,3 +146,9 @@ func NewPackage(
 
 //go:embed store.go.tmpl
 var storeTemplate string
+
+//go:embed enums.go.tmpl
+var enumsTemplate string
+
+//go:embed copy.go.tmpl
+var copyTemplate string
@@ -81,3 +81,118 @@ func TestNewPackage(t *testing.T) {
 
 	assert.Equal(t, want.GenDir, got.GenDir)
 }
+
+func TestPackage_GenerateEnums(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	genDir := path.Join(tmpDir, "gen/tmdb_pg/store")
+
+	mw := writer.NewMemoryWriters()
+
+	storePackage, err := NewPackage(
+		mw.Creator,
+		"github.com/mvoorberg/sqlxgen/gen/tmdb_pg/store",
+		genDir,
+		map[string]string{},
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	storePackage.Enums = []Enum{
+		{
+			Name:   "MpaaRating",
+			DbName: "mpaa_rating",
+			Values: []EnumValue{
+				{Name: "MpaaRatingG", Value: "G"},
+				{Name: "MpaaRatingPG13", Value: "PG-13"},
+			},
+		},
+	}
+
+	err = storePackage.Generate()
+
+	assert.Nil(t, err)
+
+	assert.Equal(t, 2, len(mw.Writers))
+
+	pen := mw.Writers[1]
+
+	assert.Equal(t, path.Join(genDir, "enums.gen.go"), pen.FullPath)
+
+	cupaloy.SnapshotT(t, pen.Content)
+}
+
+func TestPackage_GenerateEngine(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		engine     string
+		opts       map[string]string
+		wantFiles  []string
+		wantBudget string
+	}{
+		{
+			name:       "postgres",
+			engine:     "postgres",
+			opts:       map[string]string{},
+			wantFiles:  []string{"store.gen.go", "copy.gen.go"},
+			wantBudget: "var BulkInsertMaxParameters = 65535",
+		},
+		{
+			name:       "mysql",
+			engine:     "mysql",
+			opts:       map[string]string{},
+			wantFiles:  []string{"store.gen.go"},
+			wantBudget: "var BulkInsertMaxParameters = 65535",
+		},
+		{
+			name:       "sqlite",
+			engine:     "sqlite",
+			opts:       map[string]string{},
+			wantFiles:  []string{"store.gen.go"},
+			wantBudget: "var BulkInsertMaxParameters = 32766",
+		},
+		{
+			name:       "option",
+			engine:     "postgres",
+			opts:       map[string]string{"bulkInsertMaxParameters": "10000"},
+			wantFiles:  []string{"store.gen.go", "copy.gen.go"},
+			wantBudget: "var BulkInsertMaxParameters = 10000",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			genDir := path.Join(t.TempDir(), "gen/store")
+
+			mw := writer.NewMemoryWriters()
+
+			storePackage, err := NewPackage(mw.Creator, "github.com/john-doe/gen/store", genDir, testCase.opts)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			storePackage.Engine = testCase.engine
+
+			err = storePackage.Generate()
+
+			assert.Nil(t, err)
+
+			assert.Equal(t, len(testCase.wantFiles), len(mw.Writers))
+
+			for i, pen := range mw.Writers {
+				assert.Equal(t, path.Join(genDir, testCase.wantFiles[i]), pen.FullPath)
+			}
+
+			assert.Contains(t, mw.Writers[0].Content, testCase.wantBudget)
+		})
+	}
+}
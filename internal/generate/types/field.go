@@ -43,4 +43,10 @@ type GoType struct {
 	GoType    string
 	Import    string
 	IsPointer bool
+	// ImportAlias names the import of a package whose name can't be assumed
+	// from its path, like github.com/satori/go.uuid
+	ImportAlias string `json:",omitempty"`
+	// StoreImport is the store package, imported on top of Import by the
+	// types wrapped in one of its own, like store.Json
+	StoreImport string `json:",omitempty"`
 }
@@ -0,0 +1,65 @@
+package types
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/mvoorberg/sqlxgen/internal/introspect"
+)
+
+type jsonTranslate struct {
+	Translate
+}
+
+// NewJsonTranslate types the query columns annotated with
+// `-- column: x json_type: <go type>` as a store.Json of that type, before
+// the overrides and the inference of the engine.
+func NewJsonTranslate(translate Translate) Translate {
+	return jsonTranslate{Translate: translate}
+}
+
+func (t jsonTranslate) Infer(
+	storePackageDir string,
+	storePackageName string,
+	column introspect.Column,
+) (GoType, error) {
+	if !IsJsonGoType(column.JsonType) {
+		return t.Translate.Infer(storePackageDir, storePackageName, column)
+	}
+
+	return JsonGoType(storePackageDir, column.Type, column.JsonType)
+}
+
+// IsJsonGoType reports whether the json type of a column is a Go type rather
+// than one of identity, array or object.
+func IsJsonGoType(jsonType string) bool {
+	switch jsonType {
+	case "", "identity", "array", "object":
+		return false
+	}
+
+	return true
+}
+
+// JsonGoType wraps a Go type, given like the one of an override, into the
+// Json type of the store package decoding the column into it, e.g.
+// []MovieSummary becomes *store.Json[[]MovieSummary].
+func JsonGoType(storePackageDir string, dbType string, goType string) (GoType, error) {
+	parsed, err := ParseGoType(dbType, goType)
+
+	if err != nil {
+		return GoType{}, err
+	}
+
+	_, storePkg := filepath.Split(storePackageDir)
+
+	return GoType{
+		DbType:      dbType,
+		GoType:      fmt.Sprintf("*%s.Json[%s]", storePkg, strings.TrimPrefix(parsed.GoType, "*")),
+		Import:      parsed.Import,
+		ImportAlias: parsed.ImportAlias,
+		IsPointer:   true,
+		StoreImport: storePackageDir,
+	}, nil
+}
@@ -0,0 +1,134 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/mvoorberg/sqlxgen/internal/introspect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJsonGoType(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		goType string
+		want   GoType
+		err    string
+	}{
+		{
+			name:   "generated",
+			goType: "[]MovieSummary",
+			want: GoType{
+				DbType:      "jsonb",
+				GoType:      "*store.Json[[]MovieSummary]",
+				IsPointer:   true,
+				StoreImport: "github.com/john-doe/gen/store",
+			},
+		},
+		{
+			name:   "module",
+			goType: "example.com/types.Settings",
+			want: GoType{
+				DbType:      "jsonb",
+				GoType:      "*store.Json[types.Settings]",
+				Import:      "example.com/types",
+				IsPointer:   true,
+				StoreImport: "github.com/john-doe/gen/store",
+			},
+		},
+		{
+			name:   "map",
+			goType: "map[string]any",
+			want: GoType{
+				DbType:      "jsonb",
+				GoType:      "*store.Json[map[string]any]",
+				IsPointer:   true,
+				StoreImport: "github.com/john-doe/gen/store",
+			},
+		},
+		{
+			name:   "invalid",
+			goType: "chan int",
+			err:    "invalid go type chan int",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := JsonGoType("github.com/john-doe/gen/store", "jsonb", testCase.goType)
+
+			if testCase.err != "" {
+				assert.ErrorContains(t, err, testCase.err)
+
+				return
+			}
+
+			assert.Nil(t, err)
+
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
+func TestNewJsonTranslate(t *testing.T) {
+	t.Parallel()
+
+	overrides := []Override{
+		{Column: "movies.settings", GoType: "example.com/types.Settings", Json: true},
+	}
+
+	translate, err := NewOverrideTranslate(NewFakeTranslate("", ""), overrides)
+
+	assert.Nil(t, err)
+
+	translate = NewJsonTranslate(translate)
+
+	testCases := []struct {
+		name      string
+		tableName string
+		column    introspect.Column
+		want      string
+	}{
+		{
+			name:   "annotated",
+			column: introspect.Column{ColumnName: "movies", Type: "jsonb", JsonType: "[]MovieSummary"},
+			want:   "*store.Json[[]MovieSummary]",
+		},
+		{
+			name:   "json array",
+			column: introspect.Column{ColumnName: "movies", Type: "jsonb", JsonType: "array"},
+			want:   "*string",
+		},
+		{
+			name:      "override",
+			tableName: "movies",
+			column:    introspect.Column{ColumnName: "settings", Type: "jsonb"},
+			want:      "*store.Json[types.Settings]",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			tr := translate
+
+			if testCase.tableName != "" {
+				tr = ForTable(translate, "public", testCase.tableName)
+			}
+
+			got, err := tr.Infer("github.com/john-doe/gen/store", "store", testCase.column)
+
+			assert.Nil(t, err)
+
+			assert.Equal(t, testCase.want, got.GoType)
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package types
+
+import (
+	"fmt"
+	"go/token"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/joomcode/errorx"
+	"github.com/mvoorberg/sqlxgen/internal/introspect"
+)
+
+// Override replaces the inferred Go type of every column of a database type
+// (e.g. numeric, or numeric[] for arrays) or of a single column, named
+// schema.table.column or table.column. With Json, the column holds JSON
+// decoded into the Go type by a store.Json.
+type Override struct {
+	DbType string
+	Column string
+	GoType string
+	Json   bool
+}
+
+type overrideTranslate struct {
+	Translate
+	overrides  []Override
+	schemaName string
+	tableName  string
+}
+
+// NewOverrideTranslate applies the overrides before the inference of the
+// engine. Column overrides win over database type overrides, and later
+// overrides over earlier ones.
+func NewOverrideTranslate(translate Translate, overrides []Override) (Translate, error) {
+	if len(overrides) == 0 {
+		return translate, nil
+	}
+
+	for _, o := range overrides {
+		if (o.DbType == "") == (o.Column == "") {
+			return nil, errorx.IllegalArgument.New("override of %s needs exactly one of dbType or column", o.GoType)
+		}
+
+		_, err := ParseGoType(o.DbType, o.GoType)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if o.Column != "" && strings.Count(o.Column, ".") != 1 && strings.Count(o.Column, ".") != 2 {
+			return nil, errorx.IllegalArgument.New("override column %s must be table.column or schema.table.column", o.Column)
+		}
+	}
+
+	return overrideTranslate{Translate: translate, overrides: overrides}, nil
+}
+
+func (t overrideTranslate) Infer(
+	storePackageDir string,
+	storePackageName string,
+	column introspect.Column,
+) (GoType, error) {
+	dbType := column.Type
+
+	if column.IsArray {
+		dbType += "[]"
+	}
+
+	idx := t.lastIndex(func(o Override) bool { return t.matchesColumn(o, column) })
+
+	if idx < 0 {
+		idx = t.lastIndex(func(o Override) bool { return o.DbType != "" && o.DbType == dbType })
+	}
+
+	if idx >= 0 && t.overrides[idx].Json {
+		return JsonGoType(storePackageDir, column.Type, t.overrides[idx].GoType)
+	}
+
+	if idx >= 0 {
+		return ParseGoType(column.Type, t.overrides[idx].GoType)
+	}
+
+	return t.Translate.Infer(storePackageDir, storePackageName, column)
+}
+
+func (t overrideTranslate) lastIndex(matches func(o Override) bool) int {
+	for idx := len(t.overrides) - 1; idx >= 0; idx-- {
+		if matches(t.overrides[idx]) {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+func (t overrideTranslate) matchesColumn(o Override, column introspect.Column) bool {
+	if t.tableName == "" || o.Column == "" {
+		return false
+	}
+
+	parts := strings.Split(o.Column, ".")
+
+	if len(parts) == 3 && parts[0] != t.schemaName {
+		return false
+	}
+
+	return parts[len(parts)-2] == t.tableName && parts[len(parts)-1] == column.ColumnName
+}
+
+// ForTable scopes the column overrides of a translate to the columns of a
+// table, query columns only get the database type overrides.
+func ForTable(translate Translate, schemaName string, tableName string) Translate {
+	switch t := translate.(type) {
+	case overrideTranslate:
+		t.schemaName = schemaName
+
+		t.tableName = tableName
+
+		return t
+
+	case fieldStyleTranslate:
+		t.Translate = ForTable(t.Translate, schemaName, tableName)
+
+		return t
+
+	case jsonTranslate:
+		t.Translate = ForTable(t.Translate, schemaName, tableName)
+
+		return t
+	}
+
+	return translate
+}
+
+var goTypeRe = regexp.MustCompile(`^(\*?)((\[\])*)(?:(.+)\.)?(\w+)$`)
+
+var mapTypeRe = regexp.MustCompile(`^map\[(\w+)\](.+)$`)
+
+var majorVersionRe = regexp.MustCompile(`^v\d+$`)
+
+var gopkgVersionRe = regexp.MustCompile(`\.v\d+$`)
+
+// ParseGoType reads a Go type from its import path and name, e.g.
+// github.com/shopspring/decimal.Decimal, []byte or string. Types are
+// pointers, like the inferred ones, except slices, maps and interfaces that
+// are nil on their own.
+func ParseGoType(dbType string, goType string) (GoType, error) {
+	typeName, importPath, importAlias, err := parseTypeName(strings.TrimSpace(goType))
+
+	if err != nil {
+		return GoType{}, err
+	}
+
+	nilable := strings.HasPrefix(typeName, "[]") ||
+		strings.HasPrefix(typeName, "map[") ||
+		typeName == "any" ||
+		typeName == "interface{}"
+
+	if !nilable && !strings.HasPrefix(typeName, "*") {
+		typeName = "*" + typeName
+	}
+
+	return GoType{
+		DbType:      dbType,
+		GoType:      typeName,
+		Import:      importPath,
+		ImportAlias: importAlias,
+		IsPointer:   !nilable,
+	}, nil
+}
+
+// parseTypeName splits a Go type into its name, qualified by its package, its
+// import path and the alias of the import when the package name can't be
+// assumed from the path.
+func parseTypeName(goType string) (string, string, string, error) {
+	if goType == "any" || goType == "interface{}" {
+		return goType, "", "", nil
+	}
+
+	if strings.HasPrefix(goType, "map[") {
+		matches := mapTypeRe.FindStringSubmatch(goType)
+
+		if matches == nil {
+			return "", "", "", errorx.IllegalFormat.New("invalid go type %s", goType)
+		}
+
+		valueName, importPath, importAlias, err := parseTypeName(matches[2])
+
+		if err != nil {
+			return "", "", "", errorx.IllegalFormat.New("invalid go type %s", goType)
+		}
+
+		return fmt.Sprintf("map[%s]%s", matches[1], valueName), importPath, importAlias, nil
+	}
+
+	matches := goTypeRe.FindStringSubmatch(goType)
+
+	if matches == nil {
+		return "", "", "", errorx.IllegalFormat.New("invalid go type %s", goType)
+	}
+
+	star, brackets, importPath, name := matches[1], matches[2], matches[4], matches[5]
+
+	if importPath == "" {
+		return star + brackets + name, "", "", nil
+	}
+
+	pkgName, importAlias := packageName(importPath)
+
+	return fmt.Sprintf("%s%s%s.%s", star, brackets, pkgName, name), importPath, importAlias, nil
+}
+
+// packageName guesses the package name of an import path from its last
+// element, without a major version like github.com/gofrs/uuid/v5 or
+// gopkg.in/yaml.v3. An element that isn't an identifier, like go.uuid or
+// go-foo, is turned into one, returned as the alias to import the path with.
+func packageName(importPath string) (string, string) {
+	elements := strings.Split(importPath, "/")
+
+	name := elements[len(elements)-1]
+
+	if len(elements) > 1 && majorVersionRe.MatchString(name) {
+		name = elements[len(elements)-2]
+	}
+
+	name = gopkgVersionRe.ReplaceAllString(name, "")
+
+	if token.IsIdentifier(name) {
+		return name, ""
+	}
+
+	alias := identifier(name)
+
+	return alias, alias
+}
+
+// identifier turns a path element into an identifier, without the go prefix
+// or suffix of names like go-foo or foo.go and without the other characters.
+func identifier(element string) string {
+	for _, affix := range []string{"go-", "go."} {
+		element = strings.TrimPrefix(element, affix)
+	}
+
+	for _, affix := range []string{"-go", ".go"} {
+		element = strings.TrimSuffix(element, affix)
+	}
+
+	name := strings.Map(func(r rune) rune {
+		if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+
+		return -1
+	}, element)
+
+	if !token.IsIdentifier(name) {
+		name = "pkg" + name
+	}
+
+	return name
+}
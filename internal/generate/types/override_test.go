@@ -0,0 +1,253 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/mvoorberg/sqlxgen/internal/introspect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGoType(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		goType string
+		want   GoType
+		err    string
+	}{
+		{
+			name:   "builtin",
+			goType: "string",
+			want:   GoType{DbType: "text", GoType: "*string", IsPointer: true},
+		},
+		{
+			name:   "slice",
+			goType: "[]byte",
+			want:   GoType{DbType: "text", GoType: "[]byte", IsPointer: false},
+		},
+		{
+			name:   "pointer to slice",
+			goType: "*[]byte",
+			want:   GoType{DbType: "text", GoType: "*[]byte", IsPointer: true},
+		},
+		{
+			name:   "map",
+			goType: "map[string]string",
+			want:   GoType{DbType: "text", GoType: "map[string]string", IsPointer: false},
+		},
+		{
+			name:   "map of module type",
+			goType: "map[string]github.com/shopspring/decimal.Decimal",
+			want: GoType{
+				DbType:    "text",
+				GoType:    "map[string]decimal.Decimal",
+				Import:    "github.com/shopspring/decimal",
+				IsPointer: false,
+			},
+		},
+		{
+			name:   "interface",
+			goType: "any",
+			want:   GoType{DbType: "text", GoType: "any", IsPointer: false},
+		},
+		{
+			name:   "major version",
+			goType: "github.com/gofrs/uuid/v5.UUID",
+			want: GoType{
+				DbType:    "text",
+				GoType:    "*uuid.UUID",
+				Import:    "github.com/gofrs/uuid/v5",
+				IsPointer: true,
+			},
+		},
+		{
+			name:   "gopkg.in version",
+			goType: "gopkg.in/yaml.v3.Node",
+			want: GoType{
+				DbType:    "text",
+				GoType:    "*yaml.Node",
+				Import:    "gopkg.in/yaml.v3",
+				IsPointer: true,
+			},
+		},
+		{
+			name:   "dotted package",
+			goType: "github.com/satori/go.uuid.UUID",
+			want: GoType{
+				DbType:      "text",
+				GoType:      "*uuid.UUID",
+				Import:      "github.com/satori/go.uuid",
+				ImportAlias: "uuid",
+				IsPointer:   true,
+			},
+		},
+		{
+			name:   "hyphenated package",
+			goType: "github.com/x/go-foo.Type",
+			want: GoType{
+				DbType:      "text",
+				GoType:      "*foo.Type",
+				Import:      "github.com/x/go-foo",
+				ImportAlias: "foo",
+				IsPointer:   true,
+			},
+		},
+		{
+			name:   "hyphenated package in a slice",
+			goType: "[]github.com/x/foo-bar.Type",
+			want: GoType{
+				DbType:      "text",
+				GoType:      "[]foobar.Type",
+				Import:      "github.com/x/foo-bar",
+				ImportAlias: "foobar",
+				IsPointer:   false,
+			},
+		},
+		{
+			name:   "standard library",
+			goType: "encoding/json.RawMessage",
+			want:   GoType{DbType: "text", GoType: "*json.RawMessage", Import: "encoding/json", IsPointer: true},
+		},
+		{
+			name:   "module",
+			goType: "[]github.com/shopspring/decimal.Decimal",
+			want: GoType{
+				DbType:    "text",
+				GoType:    "[]decimal.Decimal",
+				Import:    "github.com/shopspring/decimal",
+				IsPointer: false,
+			},
+		},
+		{
+			name:   "invalid",
+			goType: "map[string]",
+			err:    "invalid go type map[string]",
+		},
+		{
+			name:   "invalid name",
+			goType: "func()",
+			err:    "invalid go type func()",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseGoType("text", testCase.goType)
+
+			if testCase.err != "" {
+				assert.ErrorContains(t, err, testCase.err)
+
+				return
+			}
+
+			assert.Nil(t, err)
+
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
+func TestNewOverrideTranslate(t *testing.T) {
+	t.Parallel()
+
+	overrides := []Override{
+		{DbType: "text", GoType: "database/sql.NullString"},
+		{DbType: "text[]", GoType: "github.com/lib/pq.StringArray"},
+		{Column: "movies.title", GoType: "string"},
+		{Column: "public.movies.title", GoType: "example.com/types.Title"},
+		{Column: "other.actors.name", GoType: "example.com/types.Name"},
+	}
+
+	translate, err := NewOverrideTranslate(NewFakeTranslate("", ""), overrides)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		name       string
+		schemaName string
+		tableName  string
+		column     introspect.Column
+		want       string
+	}{
+		{
+			name:   "database type",
+			column: introspect.Column{ColumnName: "title", Type: "text"},
+			want:   "*sql.NullString",
+		},
+		{
+			name:   "array database type",
+			column: introspect.Column{ColumnName: "keywords", Type: "text", IsArray: true},
+			want:   "*pq.StringArray",
+		},
+		{
+			name:   "no override",
+			column: introspect.Column{ColumnName: "id", Type: "int8"},
+			want:   "*int",
+		},
+		{
+			name:       "later column wins",
+			schemaName: "public",
+			tableName:  "movies",
+			column:     introspect.Column{ColumnName: "title", Type: "text"},
+			want:       "*types.Title",
+		},
+		{
+			name:       "column in another schema",
+			schemaName: "public",
+			tableName:  "actors",
+			column:     introspect.Column{ColumnName: "name", Type: "text"},
+			want:       "*sql.NullString",
+		},
+		{
+			name:       "column without schema",
+			schemaName: "main",
+			tableName:  "movies",
+			column:     introspect.Column{ColumnName: "title", Type: "text"},
+			want:       "*string",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			tr := translate
+
+			if testCase.tableName != "" {
+				tr = ForTable(translate, testCase.schemaName, testCase.tableName)
+			}
+
+			got, err := tr.Infer("gen/store", "github.com/john-doe/gen/store", testCase.column)
+
+			assert.Nil(t, err)
+
+			assert.Equal(t, testCase.want, got.GoType)
+		})
+	}
+
+	t.Run("invalid overrides", func(t *testing.T) {
+		t.Parallel()
+
+		invalid := [][]Override{
+			{{GoType: "string"}},
+			{{DbType: "text", Column: "movies.title", GoType: "string"}},
+			{{Column: "title", GoType: "string"}},
+			{{DbType: "text", GoType: "func()"}},
+		}
+
+		for _, overrides := range invalid {
+			_, err := NewOverrideTranslate(NewFakeTranslate("", ""), overrides)
+
+			assert.Error(t, err)
+		}
+	})
+}
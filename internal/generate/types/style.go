@@ -0,0 +1,113 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/joomcode/errorx"
+	"github.com/mvoorberg/sqlxgen/internal/introspect"
+)
+
+// Styles of the generated fields, pointer is the default.
+const (
+	// FieldStylePointer generates every field as a pointer.
+	FieldStylePointer = "pointer"
+	// FieldStyleNullable generates value types for NOT NULL columns and
+	// sql.Null for nullable ones.
+	FieldStyleNullable = "nullable"
+	// FieldStyleValue generates value types for every column.
+	FieldStyleValue = "value"
+)
+
+type fieldStyleTranslate struct {
+	Translate
+	style string
+}
+
+// NewFieldStyleTranslate turns the pointer types inferred by a translate,
+// overrides included, into the types of the given style.
+func NewFieldStyleTranslate(translate Translate, style string) (Translate, error) {
+	switch style {
+	case "", FieldStylePointer:
+		return translate, nil
+
+	case FieldStyleNullable, FieldStyleValue:
+		return fieldStyleTranslate{Translate: translate, style: style}, nil
+	}
+
+	return nil, errorx.IllegalArgument.New(
+		"unknown field style %s, expected one of %s, %s or %s",
+		style,
+		FieldStylePointer,
+		FieldStyleNullable,
+		FieldStyleValue,
+	)
+}
+
+func (t fieldStyleTranslate) Infer(
+	storePackageDir string,
+	storePackageName string,
+	column introspect.Column,
+) (GoType, error) {
+	goType, err := t.Translate.Infer(storePackageDir, storePackageName, column)
+
+	if err != nil || !goType.IsPointerType() {
+		return goType, err
+	}
+
+	goType.GoType = strings.TrimPrefix(goType.GoType, "*")
+
+	goType.IsPointer = false
+
+	// slices are already nil when null, a store.Json holds its zero value
+	if t.style == FieldStyleNullable && column.Nullable && !strings.HasPrefix(goType.GoType, "[]") && goType.StoreImport == "" {
+		goType.GoType = fmt.Sprintf("sql.Null[%s]", goType.GoType)
+	}
+
+	return goType, nil
+}
+
+// IsPointerType reports whether the Go type is a pointer, IsPointer is also
+// set for types nil on their own, like []byte or interface{}.
+func (t GoType) IsPointerType() bool {
+	return strings.HasPrefix(t.GoType, "*")
+}
+
+// IsNull reports whether the Go type is a sql.Null.
+func (t GoType) IsNull() bool {
+	return strings.HasPrefix(t.GoType, "sql.Null[")
+}
+
+// Imports of the Go type, a sql.Null needs database/sql and a store.Json the
+// store package on top of the import of the type they wrap. An aliased import
+// is the alias followed by the quoted path, see ImportSpec.
+func (t GoType) Imports() []string {
+	imports := make([]string, 0)
+
+	if t.IsNull() {
+		imports = append(imports, "database/sql")
+	}
+
+	if t.Import != "" && t.ImportAlias != "" {
+		imports = append(imports, fmt.Sprintf("%s %q", t.ImportAlias, t.Import))
+	} else if t.Import != "" {
+		imports = append(imports, t.Import)
+	}
+
+	if t.StoreImport != "" {
+		imports = append(imports, t.StoreImport)
+	}
+
+	return imports
+}
+
+// ImportSpec is the import spec of an import of Imports, quoted unless it's
+// an aliased import.
+func ImportSpec(imp string) string {
+	if strings.HasSuffix(imp, `"`) {
+		return imp
+	}
+
+	return strconv.Quote(imp)
+}
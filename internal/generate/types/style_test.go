@@ -0,0 +1,167 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/mvoorberg/sqlxgen/internal/introspect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFieldStyleTranslate(t *testing.T) {
+	t.Parallel()
+
+	columns := introspect.Columns{
+		{ColumnName: "id", Type: "int8"},
+		{ColumnName: "released_on", Type: "date", Nullable: true},
+		{ColumnName: "title", Type: "text", Nullable: true},
+	}
+
+	testCases := []struct {
+		name      string
+		style     string
+		overrides []Override
+		want      []GoType
+		err       string
+	}{
+		{
+			name:  "pointer",
+			style: FieldStylePointer,
+			want: []GoType{
+				{DbType: "int8", GoType: "*int", IsPointer: true},
+				{DbType: "date", GoType: "*time.Time", Import: "time", IsPointer: true},
+				{DbType: "text", GoType: "*string", IsPointer: true},
+			},
+		},
+		{
+			name:  "nullable",
+			style: FieldStyleNullable,
+			want: []GoType{
+				{DbType: "int8", GoType: "int"},
+				{DbType: "date", GoType: "sql.Null[time.Time]", Import: "time"},
+				{DbType: "text", GoType: "sql.Null[string]"},
+			},
+		},
+		{
+			name:  "value",
+			style: FieldStyleValue,
+			want: []GoType{
+				{DbType: "int8", GoType: "int"},
+				{DbType: "date", GoType: "time.Time", Import: "time"},
+				{DbType: "text", GoType: "string"},
+			},
+		},
+		{
+			name:      "nullable slice override",
+			style:     FieldStyleNullable,
+			overrides: []Override{{Column: "movies.title", GoType: "[]byte"}},
+			want: []GoType{
+				{DbType: "int8", GoType: "int"},
+				{DbType: "date", GoType: "sql.Null[time.Time]", Import: "time"},
+				{DbType: "text", GoType: "[]byte"},
+			},
+		},
+		{
+			name:      "nullable json override",
+			style:     FieldStyleNullable,
+			overrides: []Override{{Column: "movies.title", GoType: "example.com/types.Title", Json: true}},
+			want: []GoType{
+				{DbType: "int8", GoType: "int"},
+				{DbType: "date", GoType: "sql.Null[time.Time]", Import: "time"},
+				{DbType: "text", GoType: "store.Json[types.Title]", Import: "example.com/types", StoreImport: "gen/store"},
+			},
+		},
+		{
+			name:  "unknown",
+			style: "optional",
+			err:   "unknown field style optional",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			translate, err := NewOverrideTranslate(NewFakeTranslate("", ""), testCase.overrides)
+
+			assert.Nil(t, err)
+
+			translate, err = NewFieldStyleTranslate(translate, testCase.style)
+
+			if testCase.err != "" {
+				assert.ErrorContains(t, err, testCase.err)
+
+				return
+			}
+
+			assert.Nil(t, err)
+
+			translate = ForTable(translate, "public", "movies")
+
+			got := make([]GoType, len(columns))
+
+			for idx, column := range columns {
+				got[idx], err = translate.Infer("gen/store", "github.com/john-doe/gen/store", column)
+
+				assert.Nil(t, err)
+			}
+
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
+func TestGoType_Imports(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		goType GoType
+		want   []string
+	}{
+		{
+			name:   "builtin",
+			goType: GoType{GoType: "int64"},
+			want:   []string{},
+		},
+		{
+			name:   "pointer",
+			goType: GoType{GoType: "*time.Time", Import: "time", IsPointer: true},
+			want:   []string{"time"},
+		},
+		{
+			name:   "sql.Null",
+			goType: GoType{GoType: "sql.Null[time.Time]", Import: "time"},
+			want:   []string{"database/sql", "time"},
+		},
+		{
+			name:   "store.Json",
+			goType: GoType{GoType: "*store.Json[types.Title]", Import: "example.com/types", StoreImport: "gen/store"},
+			want:   []string{"example.com/types", "gen/store"},
+		},
+		{
+			name:   "aliased",
+			goType: GoType{GoType: "*uuid.UUID", Import: "github.com/satori/go.uuid", ImportAlias: "uuid", IsPointer: true},
+			want:   []string{`uuid "github.com/satori/go.uuid"`},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.want, testCase.goType.Imports())
+		})
+	}
+}
+
+func TestImportSpec(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `"time"`, ImportSpec("time"))
+
+	assert.Equal(t, `uuid "github.com/satori/go.uuid"`, ImportSpec(`uuid "github.com/satori/go.uuid"`))
+}
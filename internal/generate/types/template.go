@@ -0,0 +1,70 @@
+package types
+
+import (
+	"bytes"
+	"go/format"
+	"strings"
+	"text/template"
+
+	"github.com/joomcode/errorx"
+)
+
+// ExtraTemplate is rendered in addition to the model or query template, with
+// the same data and helpers. FileName is a template too.
+type ExtraTemplate struct {
+	Name     string
+	Content  string
+	FileName string
+}
+
+// Render returns the name and the content of the file, Go files are
+// formatted.
+func (e ExtraTemplate) Render(helpers template.FuncMap, data interface{}) (string, string, error) {
+	fileName, err := execute(e.Name+" file name", e.FileName, helpers, data)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	fileName = strings.TrimSpace(fileName)
+
+	if fileName == "" {
+		return "", "", errorx.IllegalArgument.New("template %s renders an empty file name", e.Name)
+	}
+
+	content, err := execute(e.Name, e.Content, helpers, data)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	if !strings.HasSuffix(fileName, ".go") {
+		return fileName, content, nil
+	}
+
+	formatted, err := format.Source([]byte(content))
+
+	if err != nil {
+		return "", "", errorx.InternalError.Wrap(err, "unable to format %s template", e.Name)
+	}
+
+	return fileName, string(formatted), nil
+}
+
+func execute(name string, content string, helpers template.FuncMap, data interface{}) (string, error) {
+	tmpl, err := template.New(name).Funcs(helpers).Parse(content)
+
+	if err != nil {
+		return "", errorx.IllegalFormat.Wrap(err, "unable to parse %s template", name)
+	}
+
+	var buffer bytes.Buffer
+
+	err = tmpl.Execute(&buffer, data)
+
+	if err != nil {
+		return "", errorx.InternalError.Wrap(err, "unable to execute %s template", name)
+	}
+
+	return buffer.String(), nil
+}
@@ -0,0 +1,86 @@
+package types
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtraTemplate_Render(t *testing.T) {
+	t.Parallel()
+
+	helpers := template.FuncMap{"ToUpper": strings.ToUpper}
+
+	data := map[string]interface{}{"Name": "actors"}
+
+	testCases := []struct {
+		name         string
+		extra        ExtraTemplate
+		wantFileName string
+		wantContent  string
+		err          string
+	}{
+		{
+			name: "go file is formatted",
+			extra: ExtraTemplate{
+				Name:     "repo.go.tmpl",
+				Content:  "package   models\nconst Table =   \"{{ .Name }}\"",
+				FileName: "{{ .Name }}_repo.gen.go",
+			},
+			wantFileName: "actors_repo.gen.go",
+			wantContent:  "package models\n\nconst Table = \"actors\"\n",
+		},
+		{
+			name: "other file is not formatted",
+			extra: ExtraTemplate{
+				Name:     "readme.md.tmpl",
+				Content:  "#   {{ ToUpper .Name }}",
+				FileName: "{{ .Name }}.md",
+			},
+			wantFileName: "actors.md",
+			wantContent:  "#   ACTORS",
+		},
+		{
+			name: "empty file name",
+			extra: ExtraTemplate{
+				Name:     "repo.go.tmpl",
+				Content:  "package models",
+				FileName: "{{ if false }}x{{ end }}",
+			},
+			err: "renders an empty file name",
+		},
+		{
+			name: "invalid template",
+			extra: ExtraTemplate{
+				Name:     "repo.go.tmpl",
+				Content:  "{{ .Name",
+				FileName: "repo.gen.go",
+			},
+			err: "unable to parse repo.go.tmpl template",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			fileName, content, err := testCase.extra.Render(helpers, data)
+
+			if testCase.err != "" {
+				assert.ErrorContains(t, err, testCase.err)
+
+				return
+			}
+
+			assert.Nil(t, err)
+
+			assert.Equal(t, testCase.wantFileName, fileName)
+
+			assert.Equal(t, testCase.wantContent, content)
+		})
+	}
+}
@@ -11,16 +11,22 @@ import (
 )
 
 type Column struct {
-	ColumnName        string `db:"column_name" json:"column_name"`
-	Type              string `db:"type" json:"type"`
-	TypeId            string `db:"type_id" json:"type_id"`
-	IsArray           bool   `db:"is_array" json:"is_array"`
-	IsSequence        bool   `db:"is_sequence" json:"is_sequence"`
-	Nullable          bool   `db:"nullable" json:"nullable"`
-	Generated         bool   `db:"generated" json:"generated"`
-	PkName            string `db:"pk_name" json:"pk_name"`
-	PkOrdinalPosition int    `db:"pk_ordinal_position" json:"pk_ordinal_position"`
-	JsonType          string `db:"json_type" json:"json_type"`
+	ColumnName        string     `db:"column_name" json:"column_name"`
+	Type              string     `db:"type" json:"type"`
+	TypeId            string     `db:"type_id" json:"type_id"`
+	IsArray           bool       `db:"is_array" json:"is_array"`
+	IsSequence        bool       `db:"is_sequence" json:"is_sequence"`
+	Nullable          bool       `db:"nullable" json:"nullable"`
+	Generated         bool       `db:"generated" json:"generated"`
+	PkName            string     `db:"pk_name" json:"pk_name"`
+	PkOrdinalPosition int        `db:"pk_ordinal_position" json:"pk_ordinal_position"`
+	JsonType          string     `db:"json_type" json:"json_type"`
+	ColumnType        string     `db:"column_type" json:"column_type,omitempty"`
+	EnumName          string     `db:"enum_name" json:"enum_name,omitempty"`
+	EnumValues        EnumValues `db:"enum_values" json:"enum_values,omitempty"`
+	// JsonKeys are the keys of the json object a struct is generated from
+	// for the Go type in JsonType
+	JsonKeys []string `db:"-" json:"json_keys,omitempty"`
 }
 
 func (column *Column) String() string {
@@ -0,0 +1,398 @@
+package ddl
+
+import (
+	"cmp"
+	"log/slog"
+	"slices"
+	"strings"
+
+	i "github.com/mvoorberg/sqlxgen/internal/introspect"
+)
+
+// catalog holds the schema built up by replaying ddl statements in order.
+type catalog struct {
+	dialect dialect
+	schema  string
+	tables  []*i.Table
+	enums   map[string]i.EnumValues
+}
+
+func newCatalog(d dialect) *catalog {
+	return &catalog{
+		dialect: d,
+		schema:  d.defaultSchema,
+		tables:  make([]*i.Table, 0),
+		enums:   make(map[string]i.EnumValues),
+	}
+}
+
+func (c *catalog) find(schemaName string, tableName string) *i.Table {
+	for _, table := range c.tables {
+		if strings.EqualFold(table.SchemaName, schemaName) && strings.EqualFold(table.TableName, tableName) {
+			return table
+		}
+	}
+
+	return nil
+}
+
+func (c *catalog) addTable(table *i.Table) {
+	c.dropTable(table.SchemaName, table.TableName)
+
+	c.tables = append(c.tables, table)
+}
+
+func (c *catalog) dropTable(schemaName string, tableName string) {
+	c.tables = slices.DeleteFunc(
+		c.tables,
+		func(table *i.Table) bool {
+			return strings.EqualFold(table.SchemaName, schemaName) && strings.EqualFold(table.TableName, tableName)
+		},
+	)
+
+	// foreign keys can't outlive the table they reference
+	for _, table := range c.tables {
+		table.ForeignKeys = slices.DeleteFunc(
+			table.ForeignKeys,
+			func(fk i.ForeignKey) bool {
+				return strings.EqualFold(fk.RefSchemaName, schemaName) && strings.EqualFold(fk.RefTableName, tableName)
+			},
+		)
+	}
+}
+
+func (c *catalog) renameTable(table *i.Table, newName string) {
+	for _, other := range c.tables {
+		for idx, fk := range other.ForeignKeys {
+			if strings.EqualFold(fk.RefSchemaName, table.SchemaName) && strings.EqualFold(fk.RefTableName, table.TableName) {
+				other.ForeignKeys[idx].RefTableName = newName
+			}
+		}
+	}
+
+	table.TableName = newName
+}
+
+func columnIndex(table *i.Table, columnName string) int {
+	return slices.IndexFunc(
+		table.Columns,
+		func(column i.Column) bool {
+			return strings.EqualFold(column.ColumnName, columnName)
+		},
+	)
+}
+
+func (c *catalog) addColumn(table *i.Table, column i.Column) {
+	if idx := columnIndex(table, column.ColumnName); idx >= 0 {
+		table.Columns[idx] = column
+
+		return
+	}
+
+	table.Columns = append(table.Columns, column)
+}
+
+func (c *catalog) dropColumn(table *i.Table, columnName string) {
+	idx := columnIndex(table, columnName)
+
+	if idx < 0 {
+		slog.Warn("dropping an unknown column", "table", table.TableName, "column", columnName)
+
+		return
+	}
+
+	if table.Columns[idx].PkOrdinalPosition > 0 {
+		c.dropPrimaryKey(table)
+	}
+
+	table.Columns = slices.Delete(table.Columns, idx, idx+1)
+
+	table.ForeignKeys = slices.DeleteFunc(
+		table.ForeignKeys,
+		func(fk i.ForeignKey) bool {
+			return containsFold(fk.Columns, columnName)
+		},
+	)
+
+	table.UniqueKeys = slices.DeleteFunc(
+		table.UniqueKeys,
+		func(uk i.UniqueKey) bool {
+			return containsFold(uk.Columns, columnName)
+		},
+	)
+}
+
+func (c *catalog) renameColumn(table *i.Table, oldName string, newName string) {
+	idx := columnIndex(table, oldName)
+
+	if idx < 0 {
+		slog.Warn("renaming an unknown column", "table", table.TableName, "column", oldName)
+
+		return
+	}
+
+	table.Columns[idx].ColumnName = newName
+
+	for fIdx, fk := range table.ForeignKeys {
+		table.ForeignKeys[fIdx].Columns = replaceFold(fk.Columns, oldName, newName)
+	}
+
+	for uIdx, uk := range table.UniqueKeys {
+		table.UniqueKeys[uIdx].Columns = replaceFold(uk.Columns, oldName, newName)
+	}
+
+	for _, other := range c.tables {
+		for fIdx, fk := range other.ForeignKeys {
+			if strings.EqualFold(fk.RefSchemaName, table.SchemaName) && strings.EqualFold(fk.RefTableName, table.TableName) {
+				other.ForeignKeys[fIdx].RefColumns = replaceFold(fk.RefColumns, oldName, newName)
+			}
+		}
+	}
+}
+
+func (c *catalog) setPrimaryKey(table *i.Table, constraintName string, columnNames []string) {
+	c.dropPrimaryKey(table)
+
+	if constraintName == "" || c.dialect.engine == "mysql" {
+		constraintName = c.dialect.primaryKeyName(table.TableName)
+	}
+
+	for position, columnName := range columnNames {
+		idx := columnIndex(table, columnName)
+
+		if idx < 0 {
+			slog.Warn("primary key on an unknown column", "table", table.TableName, "column", columnName)
+
+			continue
+		}
+
+		table.Columns[idx].PkName = constraintName
+
+		table.Columns[idx].PkOrdinalPosition = position + 1
+
+		table.Columns[idx].Nullable = false
+	}
+}
+
+func (c *catalog) dropPrimaryKey(table *i.Table) {
+	for idx := range table.Columns {
+		table.Columns[idx].PkName = ""
+
+		table.Columns[idx].PkOrdinalPosition = 0
+	}
+}
+
+func (c *catalog) addForeignKey(table *i.Table, fk i.ForeignKey) {
+	if fk.ConstraintName == "" {
+		fk.ConstraintName = c.dialect.foreignKeyName(table.TableName, fk.Columns, table.ForeignKeys)
+	}
+
+	table.ForeignKeys = append(table.ForeignKeys, fk)
+}
+
+// addUniqueKey adds a unique constraint or index, keys over expressions or
+// unknown columns are left out like the introspection does.
+func (c *catalog) addUniqueKey(table *i.Table, constraintName string, columnNames []string) {
+	if len(columnNames) == 0 {
+		return
+	}
+
+	for _, columnName := range columnNames {
+		if columnIndex(table, columnName) < 0 {
+			slog.Warn("unique key on an unknown column, skipping", "table", table.TableName, "column", columnName)
+
+			return
+		}
+	}
+
+	if constraintName == "" {
+		constraintName = c.dialect.uniqueKeyName(table.TableName, columnNames, table.UniqueKeys)
+	}
+
+	table.UniqueKeys = append(table.UniqueKeys, i.UniqueKey{ConstraintName: constraintName, Columns: columnNames})
+}
+
+// dropUniqueKey removes a unique key by name, from any table of the schema
+// when the table isn't known (e.g. drop index on postgres).
+func (c *catalog) dropUniqueKey(table *i.Table, constraintName string) {
+	tables := []*i.Table{table}
+
+	if table == nil {
+		tables = c.tables
+	}
+
+	for _, t := range tables {
+		t.UniqueKeys = slices.DeleteFunc(
+			t.UniqueKeys,
+			func(uk i.UniqueKey) bool {
+				return strings.EqualFold(uk.ConstraintName, constraintName)
+			},
+		)
+	}
+}
+
+// dropConstraint removes a foreign key, a unique key or the primary key by
+// name, other constraints don't show up in the introspection.
+func (c *catalog) dropConstraint(table *i.Table, constraintName string) {
+	table.ForeignKeys = slices.DeleteFunc(
+		table.ForeignKeys,
+		func(fk i.ForeignKey) bool {
+			return strings.EqualFold(fk.ConstraintName, constraintName)
+		},
+	)
+
+	c.dropUniqueKey(table, constraintName)
+
+	for _, column := range table.Columns {
+		if strings.EqualFold(column.PkName, constraintName) {
+			c.dropPrimaryKey(table)
+
+			return
+		}
+	}
+}
+
+func (c *catalog) renameConstraint(table *i.Table, oldName string, newName string) {
+	for idx, fk := range table.ForeignKeys {
+		if strings.EqualFold(fk.ConstraintName, oldName) {
+			table.ForeignKeys[idx].ConstraintName = newName
+		}
+	}
+
+	for idx, column := range table.Columns {
+		if strings.EqualFold(column.PkName, oldName) {
+			table.Columns[idx].PkName = newName
+		}
+	}
+
+	c.renameUniqueKey(table, oldName, newName)
+}
+
+// renameUniqueKey renames a unique key, in any table of the schema when the
+// table isn't known (e.g. alter index on postgres).
+func (c *catalog) renameUniqueKey(table *i.Table, oldName string, newName string) {
+	tables := []*i.Table{table}
+
+	if table == nil {
+		tables = c.tables
+	}
+
+	for _, t := range tables {
+		for idx, uk := range t.UniqueKeys {
+			if strings.EqualFold(uk.ConstraintName, oldName) {
+				t.UniqueKeys[idx].ConstraintName = newName
+			}
+		}
+	}
+}
+
+func (c *catalog) renameEnum(oldName string, newName string) {
+	values, ok := c.enums[oldName]
+
+	if !ok {
+		return
+	}
+
+	delete(c.enums, oldName)
+
+	c.enums[newName] = values
+
+	for _, table := range c.tables {
+		for idx, column := range table.Columns {
+			if column.Type == oldName {
+				table.Columns[idx].Type = newName
+			}
+		}
+	}
+}
+
+// result returns copies of the tables of the given schemas, sorted by name,
+// with the fields derived from the whole schema filled in.
+func (c *catalog) result(schemas []string) []i.Table {
+	tables := make([]i.Table, 0)
+
+	for _, schema := range schemas {
+		schemaTables := make([]i.Table, 0)
+
+		for _, table := range c.tables {
+			if table.SchemaName != schema {
+				continue
+			}
+
+			schemaTable := *table
+
+			schemaTable.Columns = slices.Clone(table.Columns)
+
+			schemaTable.ForeignKeys = slices.Clone(table.ForeignKeys)
+
+			schemaTable.UniqueKeys = slices.Clone(table.UniqueKeys)
+
+			schemaTables = append(schemaTables, schemaTable)
+		}
+
+		slices.SortStableFunc(
+			schemaTables,
+			func(a, b i.Table) int {
+				return cmp.Compare(a.TableName, b.TableName)
+			},
+		)
+
+		tables = append(tables, schemaTables...)
+	}
+
+	c.resolveImplicitRefColumns(tables)
+
+	for idx := range tables {
+		c.dialect.finalize(&tables[idx], c.enums)
+	}
+
+	return tables
+}
+
+// resolveImplicitRefColumns fills the referenced columns of foreign keys
+// declared without them (e.g. "references actors"), which target the primary
+// key of the parent table.
+func (c *catalog) resolveImplicitRefColumns(tables []i.Table) {
+	for _, table := range tables {
+		for idx, fk := range table.ForeignKeys {
+			if len(fk.RefColumns) > 0 {
+				continue
+			}
+
+			parent := c.find(fk.RefSchemaName, fk.RefTableName)
+
+			if parent == nil {
+				continue
+			}
+
+			refColumns := make([]string, 0)
+
+			for _, column := range parent.PrimaryKey() {
+				refColumns = append(refColumns, column.ColumnName)
+			}
+
+			table.ForeignKeys[idx].RefColumns = refColumns
+		}
+	}
+}
+
+func containsFold(values []string, value string) bool {
+	return slices.ContainsFunc(
+		values,
+		func(v string) bool {
+			return strings.EqualFold(v, value)
+		},
+	)
+}
+
+func replaceFold(values []string, oldValue string, newValue string) []string {
+	replaced := slices.Clone(values)
+
+	for idx, v := range replaced {
+		if strings.EqualFold(v, oldValue) {
+			replaced[idx] = newValue
+		}
+	}
+
+	return replaced
+}
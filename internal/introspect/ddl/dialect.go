@@ -0,0 +1,294 @@
+package ddl
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/joomcode/errorx"
+	i "github.com/mvoorberg/sqlxgen/internal/introspect"
+)
+
+// dialect reproduces what the live introspection of an engine reports, so
+// that tables parsed from ddl translate exactly like introspected ones.
+type dialect struct {
+	engine        string
+	defaultSchema string
+}
+
+func newDialect(engine string, schemas []string) (dialect, error) {
+	switch engine {
+	case "postgres":
+		return dialect{engine: engine, defaultSchema: "public"}, nil
+
+	case "mysql":
+		// unqualified mysql tables belong to the database being connected to
+		defaultSchema := ""
+
+		if len(schemas) > 0 {
+			defaultSchema = schemas[0]
+		}
+
+		return dialect{engine: engine, defaultSchema: defaultSchema}, nil
+
+	case "sqlite":
+		return dialect{engine: engine, defaultSchema: "main"}, nil
+	}
+
+	return dialect{}, errorx.IllegalArgument.New("unsupported engine %s", engine)
+}
+
+// backslashEscapes reports whether a backslash escapes a quote in a string.
+func (d dialect) backslashEscapes() bool {
+	return d.engine == "mysql"
+}
+
+// identifier folds unquoted identifiers the way the engine stores them.
+func (d dialect) identifier(t token) string {
+	if d.engine == "postgres" && t.kind == tokenWord {
+		return strings.ToLower(t.text)
+	}
+
+	return t.text
+}
+
+type typeSpec struct {
+	words     []string
+	args      []string
+	modifiers []string
+	isArray   bool
+}
+
+func (spec typeSpec) name() string {
+	return strings.Join(spec.words, " ")
+}
+
+// applyType sets the type related fields of a column from its declared type.
+func (d dialect) applyType(column *i.Column, spec typeSpec) {
+	column.TypeId = "0"
+
+	switch d.engine {
+	case "postgres":
+		column.Type, column.IsSequence = pgTypeName(spec.name())
+
+		column.IsArray = spec.isArray
+
+	case "mysql":
+		column.Type, column.ColumnType = mysqlTypeName(spec)
+
+		if spec.name() == "serial" {
+			column.IsSequence = true
+
+			column.Nullable = false
+		}
+
+	case "sqlite":
+		column.Type = spec.name()
+	}
+}
+
+var pgTypeAliases = map[string]string{
+	"smallint":                    "int2",
+	"integer":                     "int4",
+	"int":                         "int4",
+	"bigint":                      "int8",
+	"real":                        "float4",
+	"float":                       "float8",
+	"double precision":            "float8",
+	"decimal":                     "numeric",
+	"boolean":                     "bool",
+	"character varying":           "varchar",
+	"character":                   "bpchar",
+	"char":                        "bpchar",
+	"bit varying":                 "varbit",
+	"timestamp without time zone": "timestamp",
+	"timestamp with time zone":    "timestamptz",
+	"time without time zone":      "time",
+	"time with time zone":         "timetz",
+}
+
+var pgSerials = map[string]string{
+	"smallserial": "int2",
+	"serial2":     "int2",
+	"serial":      "int4",
+	"serial4":     "int4",
+	"bigserial":   "int8",
+	"serial8":     "int8",
+}
+
+func pgTypeName(name string) (string, bool) {
+	if typeName, ok := pgSerials[name]; ok {
+		return typeName, true
+	}
+
+	if typeName, ok := pgTypeAliases[name]; ok {
+		return typeName, false
+	}
+
+	return name, false
+}
+
+var mysqlTypeAliases = map[string]string{
+	"integer":           "int",
+	"bool":              "tinyint",
+	"boolean":           "tinyint",
+	"dec":               "decimal",
+	"numeric":           "decimal",
+	"fixed":             "decimal",
+	"real":              "double",
+	"double precision":  "double",
+	"character":         "char",
+	"character varying": "varchar",
+	"serial":            "bigint",
+}
+
+func mysqlTypeName(spec typeSpec) (string, string) {
+	name := spec.name()
+
+	if alias, ok := mysqlTypeAliases[name]; ok {
+		name = alias
+	}
+
+	columnType := name
+
+	switch {
+	case spec.name() == "bool" || spec.name() == "boolean":
+		columnType = "tinyint(1)"
+
+	case spec.name() == "serial":
+		columnType = "bigint unsigned"
+
+	case len(spec.args) > 0:
+		columnType = fmt.Sprintf("%s(%s)", name, strings.Join(spec.args, ","))
+	}
+
+	if len(spec.modifiers) > 0 {
+		columnType = fmt.Sprintf("%s %s", columnType, strings.Join(spec.modifiers, " "))
+	}
+
+	return name, columnType
+}
+
+func (d dialect) primaryKeyName(tableName string) string {
+	if d.engine == "mysql" {
+		return "PRIMARY"
+	}
+
+	return fmt.Sprintf("%s_pkey", tableName)
+}
+
+func (d dialect) foreignKeyName(tableName string, columns []string, existing []i.ForeignKey) string {
+	if d.engine == "mysql" {
+		// mysql numbers the generated names after the highest one in use
+		prefix := fmt.Sprintf("%s_ibfk_", tableName)
+
+		taken := 0
+
+		for _, fk := range existing {
+			number, err := strconv.Atoi(strings.TrimPrefix(fk.ConstraintName, prefix))
+
+			if err == nil && strings.HasPrefix(fk.ConstraintName, prefix) {
+				taken = max(taken, number)
+			}
+		}
+
+		return fmt.Sprintf("%s%d", prefix, taken+1)
+	}
+
+	return fmt.Sprintf("%s_%s_fkey", tableName, strings.Join(columns, "_"))
+}
+
+// uniqueKeyName names a unique key declared without a name. Mysql names it
+// after its first column, the others after the table and the columns, both
+// add a number when the name is taken.
+func (d dialect) uniqueKeyName(tableName string, columns []string, existing []i.UniqueKey) string {
+	name := fmt.Sprintf("%s_%s_key", tableName, strings.Join(columns, "_"))
+
+	format, number := "%s%d", 1
+
+	if d.engine == "mysql" {
+		name = columns[0]
+
+		format, number = "%s_%d", 2
+	}
+
+	taken := func(candidate string) bool {
+		return slices.ContainsFunc(
+			existing,
+			func(uk i.UniqueKey) bool {
+				return strings.EqualFold(uk.ConstraintName, candidate)
+			},
+		)
+	}
+
+	candidate := name
+
+	for ; taken(candidate); number++ {
+		candidate = fmt.Sprintf(format, name, number)
+	}
+
+	return candidate
+}
+
+// finalize derives the fields the engine computes rather than declares and
+// orders the columns like the live introspection does.
+func (d dialect) finalize(table *i.Table, enums map[string]i.EnumValues) {
+	primaryKey := table.PrimaryKey()
+
+	hasPrimaryKey := len(primaryKey) > 0 && primaryKey[0].PkOrdinalPosition > 0
+
+	for idx, column := range table.Columns {
+		switch d.engine {
+		case "postgres":
+			if values, ok := enums[column.Type]; ok {
+				table.Columns[idx].EnumValues = slices.Clone(values)
+			}
+
+		case "mysql":
+			if column.Type == "enum" {
+				table.Columns[idx].EnumValues = i.ParseEnumValues(column.ColumnType)
+			}
+
+		case "sqlite":
+			table.Columns[idx].IsSequence = hasPrimaryKey &&
+				len(primaryKey) == 1 &&
+				column.PkOrdinalPosition == 1 &&
+				column.Type == "integer"
+		}
+	}
+
+	slices.SortStableFunc(
+		table.Columns,
+		func(a, b i.Column) int {
+			// mysql lists the columns without a key first, the others list
+			// the primary key columns first
+			if d.engine != "mysql" && (a.PkOrdinalPosition == 0) != (b.PkOrdinalPosition == 0) {
+				return cmp.Compare(b.PkOrdinalPosition, a.PkOrdinalPosition)
+			}
+
+			ordinal := cmp.Compare(a.PkOrdinalPosition, b.PkOrdinalPosition)
+
+			if ordinal != 0 {
+				return ordinal
+			}
+
+			return cmp.Compare(a.ColumnName, b.ColumnName)
+		},
+	)
+
+	slices.SortStableFunc(
+		table.ForeignKeys,
+		func(a, b i.ForeignKey) int {
+			return cmp.Compare(a.ConstraintName, b.ConstraintName)
+		},
+	)
+
+	slices.SortStableFunc(
+		table.UniqueKeys,
+		func(a, b i.UniqueKey) int {
+			return cmp.Compare(a.ConstraintName, b.ConstraintName)
+		},
+	)
+}
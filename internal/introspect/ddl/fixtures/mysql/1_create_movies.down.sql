@@ -0,0 +1,3 @@
+drop table movies_actors;
+drop table movies;
+drop table actors;
@@ -0,0 +1,27 @@
+create table actors (
+  id int unsigned not null auto_increment primary key,
+  name varchar(255) not null unique comment 'stage name',
+  name_search varchar(255) generated always as (lower(name)) virtual
+) engine = InnoDB default charset = utf8mb4;
+
+create table `movies` (
+  `id` bigint not null auto_increment,
+  `title` varchar(255) character set utf8mb4 collate utf8mb4_bin not null,
+  `status` enum('Released','Rumored','Post Production','it''s') not null default 'Released',
+  `adult` boolean not null default false,
+  `budget` decimal(12,2) default null,
+  `keywords` json,
+  `updated_at` datetime(3) default current_timestamp(3) on update current_timestamp(3),
+  primary key (`id`),
+  key `movies_title_idx` (`title`(20)),
+  unique key `movies_title_uq` (`title`, `status`)
+) engine = InnoDB;
+
+create table movies_actors (
+  movie_id bigint not null,
+  actor_id int unsigned not null,
+  cast_order int,
+  primary key (movie_id, actor_id),
+  constraint movies_actors_movie_fk foreign key (movie_id) references movies (id) on delete cascade,
+  foreign key (actor_id) references actors (id)
+);
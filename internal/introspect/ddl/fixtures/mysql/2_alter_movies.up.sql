@@ -0,0 +1,8 @@
+alter table movies
+  add column tagline text after title,
+  modify column budget bigint unsigned,
+  change column adult is_adult tinyint(1) not null default 0;
+
+alter table movies_actors drop foreign key movies_actors_movie_fk;
+
+alter table movies rename index movies_title_uq to movies_title_status_uq;
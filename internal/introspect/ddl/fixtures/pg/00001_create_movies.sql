@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+create type mpaa_rating as enum ('G', 'PG', 'PG-13', 'R');
+
+create table public.actors (
+  id bigserial primary key,
+  name text not null,
+  name_search tsvector generated always as (to_tsvector('simple', name)) stored
+);
+
+create table movies (
+  id bigint generated always as identity,
+  title character varying(255) not null,
+  original_title text,
+  release_date date,
+  runtime integer,
+  budget numeric(12, 2),
+  vote_average double precision default 0.0,
+  keywords text[] not null default '{}'::text[],
+  rating mpaa_rating default 'G'::mpaa_rating,
+  metadata jsonb,
+  created_at timestamp with time zone not null default now(),
+  constraint movies_pk primary key (id)
+);
+
+create table movies_actors (
+  movie_id bigint not null,
+  actor_id bigint not null references actors on delete cascade,
+  "character" text,
+  cast_order smallint check (cast_order >= 0),
+  primary key (movie_id, actor_id),
+  foreign key (movie_id) references movies (id) on delete cascade on update no action
+);
+
+create index movies_actors_actor_id_idx on movies_actors (actor_id);
+
+create or replace function touch() returns trigger as $$
+begin
+  new.created_at = now();
+  return new;
+end;
+$$ language plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+drop table movies_actors;
+drop table movies;
+drop table actors;
+drop type mpaa_rating;
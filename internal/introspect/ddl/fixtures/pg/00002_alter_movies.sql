@@ -0,0 +1,29 @@
+-- +goose Up
+alter type mpaa_rating add value 'NC-17' after 'R';
+
+alter table only public.movies
+  add column tagline text,
+  drop column original_title,
+  alter column runtime set not null,
+  alter column budget type bigint using budget::bigint;
+
+alter table movies_actors rename column cast_order to billing;
+
+create table companies (
+  id serial not null,
+  name text not null unique
+);
+
+create unique index on movies (title, release_date);
+
+create unique index actors_lower_name_idx on actors (lower(name));
+
+alter table only companies alter column id set default nextval('companies_id_seq'::regclass);
+
+alter table only companies add constraint companies_pkey primary key (id);
+
+create view movie_titles as select id, title from movies;
+
+-- +goose Down
+drop view movie_titles;
+drop table companies;
@@ -0,0 +1 @@
+alter table actors drop column bio;
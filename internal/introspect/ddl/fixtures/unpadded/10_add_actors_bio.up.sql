@@ -0,0 +1 @@
+alter table actors add column bio text;
@@ -0,0 +1,4 @@
+create table movies (
+  id bigserial primary key,
+  title text not null
+);
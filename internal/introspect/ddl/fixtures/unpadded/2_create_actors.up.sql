@@ -0,0 +1,4 @@
+create table actors (
+  id bigserial primary key,
+  name text not null
+);
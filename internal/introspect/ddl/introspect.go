@@ -0,0 +1,31 @@
+package ddl
+
+import (
+	i "github.com/mvoorberg/sqlxgen/internal/introspect"
+	"github.com/mvoorberg/sqlxgen/internal/utils/fs"
+)
+
+// source builds the tables from ddl files instead of a live database, the
+// queries are still introspected by the engine source.
+type source struct {
+	args    IntrospectArgs
+	fd      fs.FileDiscovery
+	queries i.Introspect
+}
+
+func NewIntrospect(
+	fd fs.FileDiscovery,
+	args IntrospectArgs,
+	queries i.Introspect,
+) i.Introspect {
+	return source{fd: fd, args: args, queries: queries}
+}
+
+type IntrospectArgs struct {
+	Engine string
+
+	Paths           []string
+	Schemas         []string
+	TableInclusions []string
+	TableExclusions []string
+}
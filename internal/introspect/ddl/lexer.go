@@ -0,0 +1,244 @@
+package ddl
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/joomcode/errorx"
+)
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenQuoted
+	tokenString
+	tokenNumber
+	tokenSymbol
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// is reports whether the token is the given keyword, keywords are never quoted.
+func (t token) is(keyword string) bool {
+	return t.kind == tokenWord && strings.EqualFold(t.text, keyword)
+}
+
+func (t token) isSymbol(symbol string) bool {
+	return t.kind == tokenSymbol && t.text == symbol
+}
+
+// isName reports whether the token can be used as an identifier.
+func (t token) isName() bool {
+	return t.kind == tokenWord || t.kind == tokenQuoted
+}
+
+// splitStatements tokenizes a file and splits it on semicolons. Comments
+// are dropped, string and identifier quoting is resolved.
+func splitStatements(content string, backslashEscapes bool) ([][]token, error) {
+	tokens, err := tokenize(content, backslashEscapes)
+
+	if err != nil {
+		return nil, err
+	}
+
+	statements := make([][]token, 0)
+
+	statement := make([]token, 0)
+
+	for _, t := range tokens {
+		if !t.isSymbol(";") {
+			statement = append(statement, t)
+
+			continue
+		}
+
+		if len(statement) > 0 {
+			statements = append(statements, statement)
+		}
+
+		statement = make([]token, 0)
+	}
+
+	if len(statement) > 0 {
+		statements = append(statements, statement)
+	}
+
+	return statements, nil
+}
+
+var dollarQuoteRe = regexp.MustCompile(`^\$(\w*)\$`)
+
+func tokenize(content string, backslashEscapes bool) ([]token, error) {
+	tokens := make([]token, 0)
+
+	src := []rune(content)
+
+	for idx := 0; idx < len(src); {
+		ch := src[idx]
+
+		switch {
+		case unicode.IsSpace(ch):
+			idx++
+
+		case ch == '-' && idx+1 < len(src) && src[idx+1] == '-':
+			for idx < len(src) && src[idx] != '\n' {
+				idx++
+			}
+
+		case ch == '/' && idx+1 < len(src) && src[idx+1] == '*':
+			end := indexRunes(src, idx+2, []rune("*/"))
+
+			if end < 0 {
+				return nil, errorx.IllegalFormat.New("unterminated block comment")
+			}
+
+			idx = end + 2
+
+		case ch == '\'':
+			text, next, err := readQuoted(src, idx, '\'', backslashEscapes)
+
+			if err != nil {
+				return nil, err
+			}
+
+			tokens = append(tokens, token{kind: tokenString, text: text})
+
+			idx = next
+
+		case ch == '"' || ch == '`':
+			text, next, err := readQuoted(src, idx, ch, false)
+
+			if err != nil {
+				return nil, err
+			}
+
+			tokens = append(tokens, token{kind: tokenQuoted, text: text})
+
+			idx = next
+
+		case ch == '[' && len(tokens) > 0 && !tokens[len(tokens)-1].isName() && !tokens[len(tokens)-1].isSymbol("]"):
+			// sqlite and sql server style quoted identifier, array brackets
+			// always follow a type name or another bracket
+			end := indexRunes(src, idx+1, []rune("]"))
+
+			if end < 0 {
+				return nil, errorx.IllegalFormat.New("unterminated quoted identifier")
+			}
+
+			tokens = append(tokens, token{kind: tokenQuoted, text: string(src[idx+1 : end])})
+
+			idx = end + 1
+
+		case ch == '$' && dollarQuoteRe.MatchString(string(src[idx:min(idx+64, len(src))])):
+			tag := []rune(dollarQuoteRe.FindString(string(src[idx:min(idx+64, len(src))])))
+
+			start := idx + len(tag)
+
+			end := indexRunes(src, start, tag)
+
+			if end < 0 {
+				return nil, errorx.IllegalFormat.New("unterminated dollar quoted string")
+			}
+
+			tokens = append(tokens, token{kind: tokenString, text: string(src[start:end])})
+
+			idx = end + len(tag)
+
+		case unicode.IsLetter(ch) || ch == '_':
+			start := idx
+
+			for idx < len(src) && (unicode.IsLetter(src[idx]) || unicode.IsDigit(src[idx]) || src[idx] == '_' || src[idx] == '$') {
+				idx++
+			}
+
+			tokens = append(tokens, token{kind: tokenWord, text: string(src[start:idx])})
+
+		case unicode.IsDigit(ch):
+			start := idx
+
+			for idx < len(src) && (unicode.IsDigit(src[idx]) || src[idx] == '.') {
+				idx++
+			}
+
+			tokens = append(tokens, token{kind: tokenNumber, text: string(src[start:idx])})
+
+		default:
+			tokens = append(tokens, token{kind: tokenSymbol, text: string(ch)})
+
+			idx++
+		}
+	}
+
+	return tokens, nil
+}
+
+// readQuoted reads a quoted string or identifier starting at idx, a doubled
+// quote stands for the quote itself.
+func readQuoted(src []rune, idx int, quote rune, backslashEscapes bool) (string, int, error) {
+	var text strings.Builder
+
+	for next := idx + 1; next < len(src); next++ {
+		ch := src[next]
+
+		if backslashEscapes && ch == '\\' && next+1 < len(src) {
+			next++
+
+			text.WriteRune(src[next])
+
+			continue
+		}
+
+		if ch != quote {
+			text.WriteRune(ch)
+
+			continue
+		}
+
+		if next+1 < len(src) && src[next+1] == quote {
+			next++
+
+			text.WriteRune(quote)
+
+			continue
+		}
+
+		return text.String(), next + 1, nil
+	}
+
+	return "", 0, errorx.IllegalFormat.New("unterminated quoted text")
+}
+
+func indexRunes(src []rune, from int, seq []rune) int {
+	for idx := from; idx+len(seq) <= len(src); idx++ {
+		if slices.Equal(src[idx:idx+len(seq)], seq) {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+var (
+	upMarkerRe   = regexp.MustCompile(`(?im)^[ \t]*--[ \t]*(\+goose[ \t]+up|migrate:up)\b.*$`)
+	downMarkerRe = regexp.MustCompile(`(?im)^[ \t]*--[ \t]*(\+goose[ \t]+down|migrate:down)\b.*$`)
+)
+
+// upMigration keeps the up section of goose and dbmate migrations, plain
+// sql files are returned unchanged.
+func upMigration(content string) string {
+	if loc := upMarkerRe.FindStringIndex(content); loc != nil {
+		content = content[loc[1]:]
+	}
+
+	if loc := downMarkerRe.FindStringIndex(content); loc != nil {
+		content = content[:loc[0]]
+	}
+
+	return content
+}
@@ -0,0 +1,111 @@
+package ddl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitStatements(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name             string
+		content          string
+		backslashEscapes bool
+		want             [][]string
+	}{
+		{
+			name:    "comments and quoting",
+			content: "create table \"Movies\" (`id` int); -- drop table x;\n/* ; */ insert into t values ('a;''b');",
+			want: [][]string{
+				{"create", "table", "Movies", "(", "id", "int", ")"},
+				{"insert", "into", "t", "values", "(", "a;'b", ")"},
+			},
+		},
+		{
+			name:    "dollar quoted body",
+			content: "create function f() returns int as $body$ select 1; $body$ language sql;",
+			want: [][]string{
+				{"create", "function", "f", "(", ")", "returns", "int", "as", " select 1; ", "language", "sql"},
+			},
+		},
+		{
+			name:    "sqlite bracket identifier and array type",
+			content: "create table t ([order] text[]);",
+			want: [][]string{
+				{"create", "table", "t", "(", "order", "text", "[", "]", ")"},
+			},
+		},
+		{
+			name:             "backslash escapes",
+			content:          `select 'it\'s';`,
+			backslashEscapes: true,
+			want: [][]string{
+				{"select", "it's"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			statements, err := splitStatements(testCase.content, testCase.backslashEscapes)
+
+			assert.Nil(t, err)
+
+			got := make([][]string, 0)
+
+			for _, statement := range statements {
+				texts := make([]string, 0)
+
+				for _, t := range statement {
+					texts = append(texts, t.text)
+				}
+
+				got = append(got, texts)
+			}
+
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
+func TestSplitStatements_Unterminated(t *testing.T) {
+	t.Parallel()
+
+	_, err := splitStatements("create table t (name text default 'x);", false)
+
+	assert.NotNil(t, err)
+}
+
+func TestUpMigration(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "goose",
+			content: "-- +goose Up\ncreate table a (id int);\n-- +goose Down\ndrop table a;\n",
+			want:    "\ncreate table a (id int);\n",
+		},
+		{
+			name:    "dbmate",
+			content: "-- migrate:up\ncreate table a (id int);\n\n-- migrate:down\ndrop table a;\n",
+			want:    "\ncreate table a (id int);\n\n",
+		},
+		{
+			name:    "plain sql",
+			content: "create table a (id int);\n",
+			want:    "create table a (id int);\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.want, upMigration(testCase.content))
+		})
+	}
+}
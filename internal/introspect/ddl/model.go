@@ -0,0 +1,110 @@
+package ddl
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/joomcode/errorx"
+	i "github.com/mvoorberg/sqlxgen/internal/introspect"
+	"github.com/mvoorberg/sqlxgen/internal/utils"
+	"github.com/mvoorberg/sqlxgen/internal/utils/fs"
+)
+
+// IntrospectSchema replays the ddl files in the order of their versions, the
+// transaction is not used and may be nil.
+func (s source) IntrospectSchema(_ *sqlx.Tx) ([]i.Table, error) {
+	tables := make([]i.Table, 0)
+
+	validateTableName, err := utils.CreateValidateEntityNames(s.args.TableInclusions, s.args.TableExclusions)
+
+	if err != nil {
+		return tables, err
+	}
+
+	d, err := newDialect(s.args.Engine, s.args.Schemas)
+
+	if err != nil {
+		return tables, err
+	}
+
+	c := newCatalog(d)
+
+	for _, path := range s.args.Paths {
+		dfds, err := s.fd.Find(path, `\.sql$`, false)
+
+		if err != nil {
+			return tables, errorx.InitializationFailed.Wrap(err, "failed to discover ddl files")
+		}
+
+		slices.SortStableFunc(dfds, func(a fs.Discovery, b fs.Discovery) int {
+			return compareMigrations(a.GetFilename(), b.GetFilename())
+		})
+
+		for _, dfd := range dfds {
+			fileName := dfd.GetFilename()
+
+			// golang-migrate keeps the rollback in its own file
+			if strings.HasSuffix(fileName, ".down.sql") {
+				continue
+			}
+
+			content, err := dfd.Load()
+
+			if err != nil {
+				errMsg := fmt.Sprintf("failed to load ddl file %s", dfd.GetFullPath())
+
+				return tables, errorx.InitializationFailed.Wrap(err, errMsg)
+			}
+
+			statements, err := splitStatements(upMigration(string(content)), d.backslashEscapes())
+
+			if err != nil {
+				return tables, errorx.Decorate(err, msgWithFilename(fileName, "failed to split ddl statements"))
+			}
+
+			for _, statement := range statements {
+				err = c.apply(statement)
+
+				if err != nil {
+					return tables, errorx.Decorate(err, msgWithFilename(fileName, "failed to parse ddl statement"))
+				}
+			}
+		}
+	}
+
+	for _, table := range c.result(s.args.Schemas) {
+		if !validateTableName(table.TableName) {
+			continue
+		}
+
+		tables = append(tables, table)
+	}
+
+	return tables, nil
+}
+
+var migrationVersionRe = regexp.MustCompile(`^\d+`)
+
+// compareMigrations orders migration files on their leading numeric version,
+// as golang-migrate versions like 1_init.up.sql and 10_seed.up.sql aren't
+// padded, and lexically without a version or on the same version.
+func compareMigrations(a string, b string) int {
+	versionA, errA := strconv.ParseUint(migrationVersionRe.FindString(a), 10, 64)
+
+	versionB, errB := strconv.ParseUint(migrationVersionRe.FindString(b), 10, 64)
+
+	if errA == nil && errB == nil && versionA != versionB {
+		return cmp.Compare(versionA, versionB)
+	}
+
+	return strings.Compare(a, b)
+}
+
+func msgWithFilename(filename string, msg string) string {
+	return fmt.Sprintf("%s: %s", filename, msg)
+}
@@ -0,0 +1,207 @@
+package ddl
+
+import (
+	"embed"
+	"encoding/json"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy"
+	"github.com/jmoiron/sqlx"
+	i "github.com/mvoorberg/sqlxgen/internal/introspect"
+	"github.com/mvoorberg/sqlxgen/internal/introspect/sqlite"
+	"github.com/mvoorberg/sqlxgen/internal/utils"
+	"github.com/mvoorberg/sqlxgen/internal/utils/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntrospectSchema(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		engine string
+		schema string
+		dir    string
+		tables []string
+	}{
+		{
+			name:   "postgres goose migrations",
+			engine: "postgres",
+			schema: "public",
+			dir:    "fixtures/pg",
+			tables: []string{"actors", "companies", "movies", "movies_actors"},
+		},
+		{
+			name:   "mysql golang-migrate migrations",
+			engine: "mysql",
+			schema: "app",
+			dir:    "fixtures/mysql",
+			tables: []string{"actors", "movies", "movies_actors"},
+		},
+		{
+			name:   "unpadded golang-migrate versions",
+			engine: "postgres",
+			schema: "public",
+			dir:    "fixtures/unpadded",
+			tables: []string{"actors", "movies"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			args := IntrospectArgs{
+				Engine:          testCase.engine,
+				Paths:           []string{testCase.dir},
+				Schemas:         []string{testCase.schema},
+				TableInclusions: []string{},
+				TableExclusions: []string{},
+			}
+
+			source := NewIntrospect(withFixtures(t, testCase.dir), args, nil)
+
+			tables, err := source.IntrospectSchema(nil)
+
+			assert.Nil(t, err)
+
+			assert.Equal(t, len(testCase.tables), len(tables))
+
+			for idx, table := range tables {
+				assert.Equal(t, testCase.tables[idx], table.TableName)
+
+				t.Run(table.TableName, func(t *testing.T) {
+					tableJson, err := json.MarshalIndent(table, "", "  ")
+
+					if err != nil {
+						t.Fatalf("failed to marshal table: %v", err)
+					}
+
+					cupaloy.SnapshotT(t, tableJson)
+				})
+			}
+		})
+	}
+}
+
+// the ddl source must report sqlite tables exactly like the live introspection
+func TestIntrospectSchema_MatchesSqlite(t *testing.T) {
+	t.Parallel()
+
+	schemaSql, err := os.ReadFile("../sqlite/fixtures/schema.sql")
+
+	if err != nil {
+		t.Fatalf("failed to read sqlite schema: %v", err)
+	}
+
+	db, err := utils.NewTempSqlite(t.TempDir(), string(schemaSql))
+
+	if err != nil {
+		t.Fatalf("failed to create sqlite db: %v", err)
+	}
+
+	defer func(db *sqlx.DB) {
+		err := db.Close()
+
+		if err != nil {
+			t.Fatalf("failed to close sqlite db: %v", err)
+		}
+	}(db)
+
+	tx, err := db.Beginx()
+
+	if err != nil {
+		t.Fatalf("failed to create transaction: %v", err)
+	}
+
+	defer func(tx *sqlx.Tx) {
+		err := tx.Rollback()
+
+		if err != nil {
+			t.Fatalf("failed to rollback transaction: %v", err)
+		}
+	}(tx)
+
+	live, err := sqlite.NewIntrospect(nil, sqlite.IntrospectArgs{Schemas: []string{"main"}}).IntrospectSchema(tx)
+
+	if err != nil {
+		t.Fatalf("failed to introspect sqlite: %v", err)
+	}
+
+	fd := fs.NewFakeFileDiscovery(
+		[]fs.FakeDiscover{
+			{
+				Content:  string(schemaSql),
+				Dir:      "fixtures",
+				Filename: "schema.sql",
+				FullPath: "fixtures/schema.sql",
+			},
+		},
+	)
+
+	args := IntrospectArgs{
+		Engine:  "sqlite",
+		Paths:   []string{"fixtures"},
+		Schemas: []string{"main"},
+	}
+
+	tables, err := NewIntrospect(fd, args, nil).IntrospectSchema(nil)
+
+	assert.Nil(t, err)
+
+	// views can't be derived from ddl
+	want := make([]i.Table, 0)
+
+	for _, table := range live {
+		if table.TableName != "movie_titles" {
+			want = append(want, table)
+		}
+	}
+
+	assert.Equal(t, want, tables)
+}
+
+func TestIntrospectSchema_UnsupportedEngine(t *testing.T) {
+	t.Parallel()
+
+	source := NewIntrospect(fs.NewFakeFileDiscovery(nil), IntrospectArgs{Engine: "oracle"}, nil)
+
+	_, err := source.IntrospectSchema(nil)
+
+	assert.NotNil(t, err)
+}
+
+func withFixtures(t *testing.T, dir string) fs.FileDiscovery {
+	entries, err := fixtures.ReadDir(dir)
+
+	if err != nil {
+		t.Fatalf("failed to read fixtures: %v", err)
+	}
+
+	fds := make([]fs.FakeDiscover, 0)
+
+	for _, entry := range entries {
+		fullPath := path.Join(dir, entry.Name())
+
+		content, err := fixtures.ReadFile(fullPath)
+
+		if err != nil {
+			t.Fatalf("failed to read fixture: %v", err)
+		}
+
+		fds = append(
+			fds,
+			fs.FakeDiscover{
+				Content:  string(content),
+				Dir:      dir,
+				Filename: entry.Name(),
+				FullPath: fullPath,
+			},
+		)
+	}
+
+	return fs.NewFakeFileDiscovery(fds)
+}
+
+//go:embed fixtures
+var fixtures embed.FS
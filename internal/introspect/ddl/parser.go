@@ -0,0 +1,1481 @@
+package ddl
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/joomcode/errorx"
+	i "github.com/mvoorberg/sqlxgen/internal/introspect"
+)
+
+type parser struct {
+	tokens  []token
+	pos     int
+	catalog *catalog
+}
+
+// apply replays a single statement on the catalog. Statements which don't
+// change the shape of tables (inserts, plain indexes, functions...) are ignored.
+func (c *catalog) apply(statement []token) error {
+	p := &parser{tokens: statement, catalog: c}
+
+	switch {
+	case p.peekIs("create"):
+		return p.parseCreate()
+
+	case p.accept("alter", "table"):
+		return p.parseAlterTable()
+
+	case p.accept("alter", "type"):
+		return p.parseAlterType()
+
+	case p.accept("drop", "table"):
+		return p.parseDropTable()
+
+	case p.accept("drop", "type"):
+		return p.parseDropType()
+
+	case p.accept("drop", "index"):
+		return p.parseDropIndex()
+
+	case p.accept("alter", "index"):
+		return p.parseAlterIndex()
+
+	case p.accept("set", "search_path"):
+		return p.parseSearchPath()
+
+	case p.accept("use"):
+		return p.parseUse()
+	}
+
+	return nil
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *parser) peek(offset int) token {
+	if p.pos+offset >= len(p.tokens) {
+		return token{kind: tokenSymbol}
+	}
+
+	return p.tokens[p.pos+offset]
+}
+
+func (p *parser) next() token {
+	t := p.peek(0)
+
+	if !p.done() {
+		p.pos++
+	}
+
+	return t
+}
+
+func (p *parser) peekIs(keywords ...string) bool {
+	for idx, keyword := range keywords {
+		if !p.peek(idx).is(keyword) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// accept consumes the keywords when they all follow in order.
+func (p *parser) accept(keywords ...string) bool {
+	if !p.peekIs(keywords...) {
+		return false
+	}
+
+	p.pos += len(keywords)
+
+	return true
+}
+
+func (p *parser) peekSymbol(symbol string) bool {
+	return p.peek(0).isSymbol(symbol)
+}
+
+func (p *parser) acceptSymbol(symbol string) bool {
+	if !p.peekSymbol(symbol) {
+		return false
+	}
+
+	p.pos++
+
+	return true
+}
+
+func (p *parser) expectSymbol(symbol string) error {
+	if p.acceptSymbol(symbol) {
+		return nil
+	}
+
+	return p.errorf("expected %q", symbol)
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	near := p.peek(0).text
+
+	if p.done() {
+		near = "end of statement"
+	}
+
+	return errorx.IllegalFormat.New("%s near %q", fmt.Sprintf(format, args...), near)
+}
+
+func (p *parser) name() (string, error) {
+	t := p.peek(0)
+
+	if !t.isName() {
+		return "", p.errorf("expected a name")
+	}
+
+	p.pos++
+
+	return p.catalog.dialect.identifier(t), nil
+}
+
+func (p *parser) qualifiedName() (string, string, error) {
+	name, err := p.name()
+
+	if err != nil {
+		return "", "", err
+	}
+
+	schema := p.catalog.schema
+
+	for p.acceptSymbol(".") {
+		schema = name
+
+		name, err = p.name()
+
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	return schema, name, nil
+}
+
+// nameList parses a parenthesized list of column names, dropping the sort
+// order and prefix lengths of index columns.
+func (p *parser) nameList() ([]string, error) {
+	err := p.expectSymbol("(")
+
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0)
+
+	for !p.done() && !p.acceptSymbol(")") {
+		if p.acceptSymbol(",") {
+			continue
+		}
+
+		name, err := p.name()
+
+		if err != nil {
+			return nil, err
+		}
+
+		names = append(names, name)
+
+		for !p.done() && !p.peekSymbol(",") && !p.peekSymbol(")") {
+			p.skipTerm()
+		}
+	}
+
+	return names, nil
+}
+
+// skipGroup consumes a parenthesized group, including nested groups.
+func (p *parser) skipGroup() {
+	depth := 0
+
+	for !p.done() {
+		t := p.next()
+
+		if t.isSymbol("(") {
+			depth++
+		}
+
+		if t.isSymbol(")") {
+			depth--
+		}
+
+		if depth <= 0 {
+			return
+		}
+	}
+}
+
+// skipTerm consumes a single token, or a whole group when it opens one.
+func (p *parser) skipTerm() {
+	if p.peekSymbol("(") {
+		p.skipGroup()
+
+		return
+	}
+
+	p.next()
+}
+
+// skipElement consumes everything up to the next comma or closing
+// parenthesis of the enclosing list.
+func (p *parser) skipElement() {
+	for !p.done() && !p.peekSymbol(",") && !p.peekSymbol(")") {
+		p.skipTerm()
+	}
+}
+
+// skipExpression consumes a default or generation expression, stopping at
+// the next column constraint.
+func (p *parser) skipExpression() string {
+	first := p.peek(0)
+
+	p.skipTerm()
+
+	if first.isSymbol("-") || first.isSymbol("+") {
+		p.skipTerm()
+	}
+
+	for !p.done() {
+		switch {
+		case p.peekSymbol("("):
+			p.skipGroup()
+
+		case p.peekSymbol(":") && p.peek(1).isSymbol(":"):
+			p.pos += 2
+
+			p.parseType()
+
+		case p.peekOperator():
+			p.next()
+
+			p.skipTerm()
+
+		default:
+			return strings.ToLower(first.text)
+		}
+	}
+
+	return strings.ToLower(first.text)
+}
+
+func (p *parser) peekOperator() bool {
+	t := p.peek(0)
+
+	return t.kind == tokenSymbol && strings.Contains("+-*/|%", t.text)
+}
+
+func (p *parser) parseCreate() error {
+	p.accept("create")
+
+	p.accept("or", "replace")
+
+	p.accept("global")
+
+	p.accept("local")
+
+	temporary := p.accept("temp") || p.accept("temporary")
+
+	p.accept("unlogged")
+
+	switch {
+	case p.accept("table"):
+		if temporary {
+			return nil
+		}
+
+		return p.parseCreateTable()
+
+	case p.accept("type"):
+		return p.parseCreateType()
+
+	case p.accept("unique", "index"):
+		return p.parseCreateUniqueIndex()
+
+	case p.peekIs("view") || p.peekIs("materialized", "view"):
+		p.accept("materialized")
+
+		p.accept("view")
+
+		p.accept("if", "not", "exists")
+
+		_, name, err := p.qualifiedName()
+
+		if err != nil {
+			return err
+		}
+
+		slog.Warn("views are not supported by the ddl source, skipping", "view", name)
+	}
+
+	return nil
+}
+
+// parseCreateUniqueIndex adds the unique key of a unique index, other
+// indexes don't show up in the introspection.
+func (p *parser) parseCreateUniqueIndex() error {
+	p.accept("concurrently")
+
+	p.accept("if", "not", "exists")
+
+	// postgres can leave the index unnamed
+	indexName := ""
+
+	if !p.peekIs("on") {
+		_, name, err := p.qualifiedName()
+
+		if err != nil {
+			return err
+		}
+
+		indexName = name
+	}
+
+	if p.accept("using") {
+		p.next()
+	}
+
+	if !p.accept("on") {
+		return p.errorf("expected on")
+	}
+
+	p.accept("only")
+
+	schema, tableName, err := p.qualifiedName()
+
+	if err != nil {
+		return err
+	}
+
+	table := p.catalog.find(schema, tableName)
+
+	if table == nil {
+		slog.Warn("indexing an unknown table, skipping", "table", tableName)
+
+		return nil
+	}
+
+	if p.accept("using") {
+		p.next()
+	}
+
+	columns := p.keyColumns()
+
+	for !p.done() {
+		// partial indexes can't be targeted by their columns alone
+		if p.peekIs("where") {
+			return nil
+		}
+
+		p.skipTerm()
+	}
+
+	// postgres names unnamed indexes after the table and the columns
+	if indexName == "" && len(columns) > 0 {
+		indexName = fmt.Sprintf("%s_%s_idx", table.TableName, strings.Join(columns, "_"))
+	}
+
+	p.catalog.addUniqueKey(table, indexName, columns)
+
+	return nil
+}
+
+func (p *parser) parseCreateTable() error {
+	ifNotExists := p.accept("if", "not", "exists")
+
+	schema, name, err := p.qualifiedName()
+
+	if err != nil {
+		return err
+	}
+
+	if ifNotExists && p.catalog.find(schema, name) != nil {
+		return nil
+	}
+
+	table := &i.Table{
+		SchemaName:  schema,
+		TableName:   name,
+		Columns:     make(i.Columns, 0),
+		ForeignKeys: make(i.ForeignKeys, 0),
+		UniqueKeys:  make(i.UniqueKeys, 0),
+	}
+
+	if p.accept("like") {
+		return p.copyTable(table, true)
+	}
+
+	if p.peekIs("as") || p.peekIs("partition", "of") {
+		slog.Warn("tables created from a query or as a partition are not supported by the ddl source, skipping", "table", name)
+
+		return nil
+	}
+
+	err = p.expectSymbol("(")
+
+	if err != nil {
+		return err
+	}
+
+	p.catalog.addTable(table)
+
+	for !p.done() && !p.acceptSymbol(")") {
+		if p.acceptSymbol(",") {
+			continue
+		}
+
+		if p.accept("like") {
+			err = p.copyTable(table, false)
+		} else if p.peekTableConstraint() {
+			err = p.parseTableConstraint(table)
+		} else {
+			err = p.parseColumn(table)
+		}
+
+		if err != nil {
+			return errorx.Decorate(err, "failed to parse table %s", name)
+		}
+	}
+
+	return nil
+}
+
+// copyTable copies the columns of another table, mysql also copies its
+// primary and unique keys. Foreign keys are never copied.
+func (p *parser) copyTable(table *i.Table, topLevel bool) error {
+	schema, name, err := p.qualifiedName()
+
+	if err != nil {
+		return err
+	}
+
+	if !topLevel {
+		p.skipElement()
+	}
+
+	source := p.catalog.find(schema, name)
+
+	if source == nil {
+		return p.errorf("unknown table %s", name)
+	}
+
+	table.Columns = slices.Clone(source.Columns)
+
+	if p.catalog.dialect.engine == "mysql" {
+		table.UniqueKeys = slices.Clone(source.UniqueKeys)
+	} else {
+		for idx := range table.Columns {
+			table.Columns[idx].PkName = ""
+
+			table.Columns[idx].PkOrdinalPosition = 0
+		}
+	}
+
+	if topLevel {
+		p.catalog.addTable(table)
+	}
+
+	return nil
+}
+
+func (p *parser) peekTableConstraint() bool {
+	switch {
+	case p.peekIs("constraint"), p.peekIs("primary", "key"), p.peekIs("foreign", "key"):
+		return true
+
+	case p.peekIs("unique"), p.peekIs("exclude"), p.peekIs("fulltext"), p.peekIs("spatial"):
+		return true
+
+	case p.peekIs("check") && p.peek(1).isSymbol("("):
+		return true
+
+	case p.peekIs("key") || p.peekIs("index"):
+		return p.peek(1).isSymbol("(") || p.peek(2).isSymbol("(")
+	}
+
+	return false
+}
+
+func (p *parser) parseTableConstraint(table *i.Table) error {
+	constraintName := ""
+
+	if p.accept("constraint") {
+		name, err := p.name()
+
+		if err != nil {
+			return err
+		}
+
+		constraintName = name
+	}
+
+	switch {
+	case p.accept("primary", "key"):
+		columns, err := p.nameList()
+
+		if err != nil {
+			return err
+		}
+
+		p.catalog.setPrimaryKey(table, constraintName, columns)
+
+	case p.accept("foreign", "key"):
+		// mysql accepts an index name before the columns
+		if !p.peekSymbol("(") {
+			p.next()
+		}
+
+		columns, err := p.nameList()
+
+		if err != nil {
+			return err
+		}
+
+		if !p.accept("references") {
+			return p.errorf("expected references")
+		}
+
+		fk, err := p.parseReferences(constraintName, columns)
+
+		if err != nil {
+			return err
+		}
+
+		p.catalog.addForeignKey(table, fk)
+
+	case p.accept("unique"):
+		if !p.accept("key") {
+			p.accept("index")
+		}
+
+		p.accept("nulls", "not", "distinct")
+
+		p.accept("nulls", "distinct")
+
+		// mysql accepts an index name before the columns
+		if !p.peekSymbol("(") && !p.peekIs("using") {
+			indexName, err := p.name()
+
+			if err != nil {
+				return err
+			}
+
+			constraintName = indexName
+		}
+
+		// sqlite doesn't keep the names of unique constraints
+		if p.catalog.dialect.engine == "sqlite" {
+			constraintName = ""
+		}
+
+		if p.peekSymbol("(") {
+			p.catalog.addUniqueKey(table, constraintName, p.keyColumns())
+		}
+	}
+
+	p.skipElement()
+
+	return nil
+}
+
+// keyColumns parses the column list of a key, a list holding expressions
+// is skipped and returns no columns.
+func (p *parser) keyColumns() []string {
+	start := p.pos
+
+	columns, err := p.nameList()
+
+	if err != nil {
+		p.pos = start
+
+		p.skipGroup()
+
+		return nil
+	}
+
+	return columns
+}
+
+func (p *parser) parseReferences(constraintName string, columns []string) (i.ForeignKey, error) {
+	refSchema, refTable, err := p.qualifiedName()
+
+	if err != nil {
+		return i.ForeignKey{}, err
+	}
+
+	refColumns := make([]string, 0)
+
+	if p.peekSymbol("(") {
+		refColumns, err = p.nameList()
+
+		if err != nil {
+			return i.ForeignKey{}, err
+		}
+	}
+
+	fk := i.ForeignKey{
+		ConstraintName: constraintName,
+		Columns:        columns,
+		RefSchemaName:  refSchema,
+		RefTableName:   refTable,
+		RefColumns:     refColumns,
+		OnDelete:       "NO ACTION",
+		OnUpdate:       "NO ACTION",
+	}
+
+	for {
+		switch {
+		case p.accept("on", "delete"):
+			fk.OnDelete = p.parseReferentialAction()
+
+		case p.accept("on", "update"):
+			fk.OnUpdate = p.parseReferentialAction()
+
+		case p.accept("match"):
+			p.next()
+
+		case p.accept("not", "deferrable"), p.accept("deferrable"):
+
+		case p.accept("initially"):
+			p.next()
+
+		default:
+			return fk, nil
+		}
+	}
+}
+
+func (p *parser) parseReferentialAction() string {
+	switch {
+	case p.accept("cascade"):
+		return "CASCADE"
+
+	case p.accept("restrict"):
+		return "RESTRICT"
+
+	case p.accept("no", "action"):
+		return "NO ACTION"
+
+	case p.accept("set", "null"):
+		if p.peekSymbol("(") {
+			p.skipGroup()
+		}
+
+		return "SET NULL"
+
+	case p.accept("set", "default"):
+		if p.peekSymbol("(") {
+			p.skipGroup()
+		}
+
+		return "SET DEFAULT"
+	}
+
+	return strings.ToUpper(p.next().text)
+}
+
+var columnConstraintKeywords = []string{
+	"constraint", "not", "null", "primary", "unique", "references", "default",
+	"check", "generated", "auto_increment", "autoincrement", "collate",
+	"comment", "as", "on", "key", "visible", "invisible",
+	// clauses which may follow a type in alter table
+	"after", "first", "using",
+}
+
+func isColumnConstraint(t token) bool {
+	return slices.ContainsFunc(columnConstraintKeywords, t.is)
+}
+
+// parseType parses a declared type such as "character varying(255)",
+// "timestamp(3) with time zone", "int unsigned", "text[]" or
+// "enum('a','b')".
+func (p *parser) parseType() typeSpec {
+	spec := typeSpec{
+		words:     make([]string, 0),
+		args:      make([]string, 0),
+		modifiers: make([]string, 0),
+	}
+
+	for !p.done() {
+		t := p.peek(0)
+
+		switch {
+		case t.isName() && p.peek(1).isSymbol("."):
+			// schema qualified type
+			p.pos += 2
+
+		case t.kind == tokenQuoted && len(spec.words) == 0:
+			spec.words = append(spec.words, p.catalog.dialect.identifier(p.next()))
+
+		case t.kind != tokenWord || isColumnConstraint(t):
+			return spec
+
+		case t.is("character") && p.peek(1).is("set"), t.is("charset"):
+			return spec
+
+		case t.is("unsigned"), t.is("signed"), t.is("zerofill"):
+			spec.modifiers = append(spec.modifiers, strings.ToLower(p.next().text))
+
+		case t.is("array"):
+			p.next()
+
+			spec.isArray = true
+
+		default:
+			spec.words = append(spec.words, strings.ToLower(p.next().text))
+		}
+
+		for p.peekSymbol("(") || p.peekSymbol("[") {
+			if p.acceptSymbol("[") {
+				spec.isArray = true
+
+				for !p.done() && !p.acceptSymbol("]") {
+					p.next()
+				}
+
+				continue
+			}
+
+			spec.args = append(spec.args, p.parseTypeArgs()...)
+		}
+	}
+
+	return spec
+}
+
+func (p *parser) parseTypeArgs() []string {
+	args := make([]string, 0)
+
+	p.acceptSymbol("(")
+
+	for !p.done() && !p.acceptSymbol(")") {
+		t := p.next()
+
+		switch {
+		case t.isSymbol(","):
+
+		case t.kind == tokenString:
+			args = append(args, fmt.Sprintf("'%s'", strings.ReplaceAll(t.text, "'", "''")))
+
+		default:
+			args = append(args, strings.ToLower(t.text))
+		}
+	}
+
+	return args
+}
+
+// parseColumn parses a column definition with its inline constraints and
+// adds it to the table.
+func (p *parser) parseColumn(table *i.Table) error {
+	columnName, err := p.name()
+
+	if err != nil {
+		return err
+	}
+
+	column := i.Column{
+		ColumnName: columnName,
+		Nullable:   true,
+	}
+
+	p.catalog.dialect.applyType(&column, p.parseType())
+
+	primaryKey := false
+
+	pkName := ""
+
+	fks := make([]i.ForeignKey, 0)
+
+	uniqueNames := make([]string, 0)
+
+	for !p.done() && !p.peekSymbol(",") && !p.peekSymbol(")") {
+		constraintName := ""
+
+		if p.accept("constraint") {
+			constraintName, err = p.name()
+
+			if err != nil {
+				return err
+			}
+		}
+
+		switch {
+		case p.accept("not", "null"):
+			column.Nullable = false
+
+		case p.accept("null"):
+
+		case p.accept("primary", "key"):
+			primaryKey = true
+
+			pkName = constraintName
+
+			p.accept("asc")
+
+			p.accept("desc")
+
+		case p.accept("unique"):
+			p.accept("key")
+
+			p.accept("nulls", "not", "distinct")
+
+			p.accept("nulls", "distinct")
+
+			// sqlite doesn't keep the names of unique constraints
+			if p.catalog.dialect.engine == "sqlite" {
+				constraintName = ""
+			}
+
+			uniqueNames = append(uniqueNames, constraintName)
+
+		case p.accept("key"):
+			// mysql shorthand for primary key
+			primaryKey = true
+
+		case p.accept("references"):
+			fk, err := p.parseReferences(constraintName, []string{columnName})
+
+			if err != nil {
+				return err
+			}
+
+			fks = append(fks, fk)
+
+		case p.accept("default"):
+			if p.skipExpression() == "nextval" && p.catalog.dialect.engine == "postgres" {
+				column.IsSequence = true
+			}
+
+		case p.accept("check"):
+			p.skipTerm()
+
+		case p.accept("generated"):
+			err = p.parseGenerated(&column)
+
+			if err != nil {
+				return err
+			}
+
+		case p.accept("as"):
+			p.skipTerm()
+
+			column.Generated = true
+
+		case p.accept("auto_increment"), p.accept("autoincrement"):
+			column.IsSequence = p.catalog.dialect.engine == "mysql"
+
+		case p.accept("collate"), p.accept("comment"), p.accept("charset"), p.accept("character", "set"):
+			p.next()
+
+		case p.accept("on"):
+			// on update current_timestamp, on conflict replace
+			p.next()
+
+			p.skipExpression()
+
+		default:
+			p.skipTerm()
+		}
+	}
+
+	p.catalog.addColumn(table, column)
+
+	if primaryKey {
+		p.catalog.setPrimaryKey(table, pkName, []string{columnName})
+	}
+
+	for _, fk := range fks {
+		p.catalog.addForeignKey(table, fk)
+	}
+
+	for _, uniqueName := range uniqueNames {
+		p.catalog.addUniqueKey(table, uniqueName, []string{columnName})
+	}
+
+	return nil
+}
+
+// parseGenerated parses identity and computed columns, after "generated".
+func (p *parser) parseGenerated(column *i.Column) error {
+	if !p.accept("always") && !p.accept("by", "default") {
+		return p.errorf("expected always or by default")
+	}
+
+	if !p.accept("as") {
+		return p.errorf("expected as")
+	}
+
+	if p.accept("identity") {
+		// identity columns have no nextval default, they aren't reported as sequences
+		column.Nullable = false
+
+		if p.peekSymbol("(") {
+			p.skipGroup()
+		}
+
+		return nil
+	}
+
+	p.skipTerm()
+
+	p.accept("stored")
+
+	p.accept("virtual")
+
+	column.Generated = true
+
+	return nil
+}
+
+func (p *parser) parseAlterTable() error {
+	p.accept("if", "exists")
+
+	p.accept("only")
+
+	schema, name, err := p.qualifiedName()
+
+	if err != nil {
+		return err
+	}
+
+	table := p.catalog.find(schema, name)
+
+	if table == nil {
+		slog.Warn("altering an unknown table, skipping", "table", name)
+
+		return nil
+	}
+
+	for !p.done() {
+		if p.acceptSymbol(",") || p.acceptSymbol(")") {
+			continue
+		}
+
+		err = p.parseAlterAction(table)
+
+		if err != nil {
+			return errorx.Decorate(err, "failed to parse alter table %s", name)
+		}
+
+		p.skipElement()
+	}
+
+	return nil
+}
+
+func (p *parser) parseAlterAction(table *i.Table) error {
+	switch {
+	case p.accept("add"):
+		return p.parseAlterAdd(table)
+
+	case p.accept("drop"):
+		return p.parseAlterDrop(table)
+
+	case p.accept("rename"):
+		return p.parseAlterRename(table)
+
+	case p.accept("alter"):
+		p.accept("column")
+
+		columnName, err := p.name()
+
+		if err != nil {
+			return err
+		}
+
+		return p.parseAlterColumn(table, columnName)
+
+	case p.accept("modify"):
+		p.accept("column")
+
+		return p.parseReplaceColumn(table, "")
+
+	case p.accept("change"):
+		p.accept("column")
+
+		oldName, err := p.name()
+
+		if err != nil {
+			return err
+		}
+
+		return p.parseReplaceColumn(table, oldName)
+	}
+
+	return nil
+}
+
+func (p *parser) parseAlterAdd(table *i.Table) error {
+	hasColumnKeyword := p.accept("column")
+
+	if !hasColumnKeyword && p.peekTableConstraint() {
+		return p.parseTableConstraint(table)
+	}
+
+	ifNotExists := p.accept("if", "not", "exists")
+
+	// mysql can add several columns at once
+	if p.acceptSymbol("(") {
+		for !p.done() && !p.acceptSymbol(")") {
+			if p.acceptSymbol(",") {
+				continue
+			}
+
+			err := p.parseColumn(table)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if ifNotExists && p.peek(0).isName() && columnIndex(table, p.catalog.dialect.identifier(p.peek(0))) >= 0 {
+		return nil
+	}
+
+	return p.parseColumn(table)
+}
+
+func (p *parser) parseAlterDrop(table *i.Table) error {
+	switch {
+	case p.accept("constraint"), p.accept("foreign", "key"), p.accept("check"):
+		p.accept("if", "exists")
+
+		name, err := p.name()
+
+		if err != nil {
+			return err
+		}
+
+		p.catalog.dropConstraint(table, name)
+
+		return nil
+
+	case p.accept("primary", "key"):
+		p.catalog.dropPrimaryKey(table)
+
+		return nil
+
+	case p.accept("index"), p.accept("key"):
+		name, err := p.name()
+
+		if err != nil {
+			return err
+		}
+
+		p.catalog.dropUniqueKey(table, name)
+
+		return nil
+
+	case p.accept("default"):
+		return nil
+	}
+
+	p.accept("column")
+
+	p.accept("if", "exists")
+
+	name, err := p.name()
+
+	if err != nil {
+		return err
+	}
+
+	p.catalog.dropColumn(table, name)
+
+	return nil
+}
+
+func (p *parser) parseAlterRename(table *i.Table) error {
+	switch {
+	case p.accept("to"), p.accept("as"):
+		_, name, err := p.qualifiedName()
+
+		if err != nil {
+			return err
+		}
+
+		p.catalog.renameTable(table, name)
+
+		return nil
+
+	case p.accept("index"), p.accept("key"):
+		oldName, newName, err := p.parseRenamePair()
+
+		if err != nil {
+			return err
+		}
+
+		p.catalog.renameUniqueKey(table, oldName, newName)
+
+		return nil
+
+	case p.accept("constraint"):
+		oldName, newName, err := p.parseRenamePair()
+
+		if err != nil {
+			return err
+		}
+
+		p.catalog.renameConstraint(table, oldName, newName)
+
+		return nil
+	}
+
+	p.accept("column")
+
+	oldName, newName, err := p.parseRenamePair()
+
+	if err != nil {
+		return err
+	}
+
+	p.catalog.renameColumn(table, oldName, newName)
+
+	return nil
+}
+
+func (p *parser) parseRenamePair() (string, string, error) {
+	oldName, err := p.name()
+
+	if err != nil {
+		return "", "", err
+	}
+
+	if !p.accept("to") {
+		return "", "", p.errorf("expected to")
+	}
+
+	newName, err := p.name()
+
+	if err != nil {
+		return "", "", err
+	}
+
+	return oldName, newName, nil
+}
+
+func (p *parser) parseAlterColumn(table *i.Table, columnName string) error {
+	idx := columnIndex(table, columnName)
+
+	if idx < 0 {
+		slog.Warn("altering an unknown column", "table", table.TableName, "column", columnName)
+
+		return nil
+	}
+
+	column := &table.Columns[idx]
+
+	switch {
+	case p.accept("set", "not", "null"):
+		column.Nullable = false
+
+	case p.accept("drop", "not", "null"):
+		column.Nullable = column.PkOrdinalPosition == 0
+
+	case p.accept("set", "default"):
+		if p.skipExpression() == "nextval" && p.catalog.dialect.engine == "postgres" {
+			column.IsSequence = true
+		}
+
+	case p.accept("drop", "default"):
+		column.IsSequence = column.IsSequence && p.catalog.dialect.engine != "postgres"
+
+	case p.accept("add", "generated"):
+		return p.parseGenerated(column)
+
+	case p.accept("set", "data", "type"), p.accept("type"):
+		spec := p.parseType()
+
+		isSequence := column.IsSequence
+
+		p.catalog.dialect.applyType(column, spec)
+
+		column.IsSequence = column.IsSequence || isSequence
+	}
+
+	return nil
+}
+
+// parseReplaceColumn handles the mysql modify and change clauses, which
+// redefine a column entirely, keeping its place in the primary key.
+func (p *parser) parseReplaceColumn(table *i.Table, oldName string) error {
+	if oldName == "" && p.peek(0).isName() {
+		oldName = p.catalog.dialect.identifier(p.peek(0))
+	}
+
+	idx := columnIndex(table, oldName)
+
+	if idx < 0 {
+		slog.Warn("modifying an unknown column", "table", table.TableName, "column", oldName)
+
+		return nil
+	}
+
+	previous := table.Columns[idx]
+
+	replacement := &i.Table{TableName: table.TableName, Columns: make(i.Columns, 0)}
+
+	err := p.parseColumn(replacement)
+
+	if err != nil {
+		return err
+	}
+
+	column := replacement.Columns[0]
+
+	column.PkName = previous.PkName
+
+	column.PkOrdinalPosition = previous.PkOrdinalPosition
+
+	column.Nullable = column.Nullable && previous.PkOrdinalPosition == 0
+
+	table.Columns[idx] = column
+
+	// go through renameColumn so that the foreign keys follow the new name
+	if !strings.EqualFold(oldName, column.ColumnName) {
+		table.Columns[idx].ColumnName = oldName
+
+		p.catalog.renameColumn(table, oldName, column.ColumnName)
+	}
+
+	return nil
+}
+
+func (p *parser) parseDropTable() error {
+	p.accept("if", "exists")
+
+	for !p.done() {
+		if p.acceptSymbol(",") {
+			continue
+		}
+
+		if p.peekIs("cascade") || p.peekIs("restrict") {
+			return nil
+		}
+
+		schema, name, err := p.qualifiedName()
+
+		if err != nil {
+			return err
+		}
+
+		p.catalog.dropTable(schema, name)
+	}
+
+	return nil
+}
+
+// parseDropIndex drops the unique key of an index, mysql names the table
+// while postgres and sqlite look the index up in the schema.
+func (p *parser) parseDropIndex() error {
+	p.accept("concurrently")
+
+	p.accept("if", "exists")
+
+	for !p.done() {
+		if p.acceptSymbol(",") {
+			continue
+		}
+
+		if p.peekIs("cascade") || p.peekIs("restrict") {
+			return nil
+		}
+
+		_, name, err := p.qualifiedName()
+
+		if err != nil {
+			return err
+		}
+
+		if p.accept("on") {
+			schema, tableName, err := p.qualifiedName()
+
+			if err != nil {
+				return err
+			}
+
+			if table := p.catalog.find(schema, tableName); table != nil {
+				p.catalog.dropUniqueKey(table, name)
+			}
+
+			return nil
+		}
+
+		p.catalog.dropUniqueKey(nil, name)
+	}
+
+	return nil
+}
+
+// parseAlterIndex follows the renames of indexes on postgres.
+func (p *parser) parseAlterIndex() error {
+	p.accept("if", "exists")
+
+	_, oldName, err := p.qualifiedName()
+
+	if err != nil {
+		return err
+	}
+
+	if !p.accept("rename", "to") {
+		return nil
+	}
+
+	newName, err := p.name()
+
+	if err != nil {
+		return err
+	}
+
+	p.catalog.renameUniqueKey(nil, oldName, newName)
+
+	return nil
+}
+
+func (p *parser) parseCreateType() error {
+	_, name, err := p.qualifiedName()
+
+	if err != nil {
+		return err
+	}
+
+	if !p.accept("as", "enum") {
+		return nil
+	}
+
+	err = p.expectSymbol("(")
+
+	if err != nil {
+		return err
+	}
+
+	values := make(i.EnumValues, 0)
+
+	for !p.done() && !p.acceptSymbol(")") {
+		t := p.next()
+
+		if t.kind == tokenString {
+			values = append(values, t.text)
+		}
+	}
+
+	p.catalog.enums[name] = values
+
+	return nil
+}
+
+func (p *parser) parseAlterType() error {
+	_, name, err := p.qualifiedName()
+
+	if err != nil {
+		return err
+	}
+
+	values, ok := p.catalog.enums[name]
+
+	if !ok {
+		return nil
+	}
+
+	switch {
+	case p.accept("add", "value"):
+		p.accept("if", "not", "exists")
+
+		label := p.next().text
+
+		if slices.Contains(values, label) {
+			return nil
+		}
+
+		position := len(values)
+
+		if p.accept("before") {
+			position = max(slices.Index(values, p.next().text), 0)
+		} else if p.accept("after") {
+			position = slices.Index(values, p.next().text) + 1
+		}
+
+		p.catalog.enums[name] = slices.Insert(values, position, label)
+
+	case p.accept("rename", "value"):
+		oldLabel := p.next().text
+
+		p.accept("to")
+
+		newLabel := p.next().text
+
+		p.catalog.enums[name] = replaceFold(values, oldLabel, newLabel)
+
+	case p.accept("rename", "to"):
+		newName, err := p.name()
+
+		if err != nil {
+			return err
+		}
+
+		p.catalog.renameEnum(name, newName)
+	}
+
+	return nil
+}
+
+func (p *parser) parseDropType() error {
+	p.accept("if", "exists")
+
+	for !p.done() && !p.peekIs("cascade") && !p.peekIs("restrict") {
+		if p.acceptSymbol(",") {
+			continue
+		}
+
+		_, name, err := p.qualifiedName()
+
+		if err != nil {
+			return err
+		}
+
+		delete(p.catalog.enums, name)
+	}
+
+	return nil
+}
+
+// parseSearchPath follows "set search_path to x", unqualified names then
+// belong to the first schema of the path.
+func (p *parser) parseSearchPath() error {
+	if !p.accept("to") && !p.acceptSymbol("=") {
+		return nil
+	}
+
+	t := p.next()
+
+	if t.isName() || t.kind == tokenString {
+		p.catalog.schema = p.catalog.dialect.identifier(t)
+	}
+
+	return nil
+}
+
+func (p *parser) parseUse() error {
+	name, err := p.name()
+
+	if err != nil {
+		return err
+	}
+
+	p.catalog.schema = name
+
+	return nil
+}
@@ -0,0 +1,10 @@
+package ddl
+
+import (
+	"github.com/jmoiron/sqlx"
+	i "github.com/mvoorberg/sqlxgen/internal/introspect"
+)
+
+func (s source) IntrospectQueries(tx *sqlx.Tx) ([]i.Query, error) {
+	return s.queries.IntrospectQueries(tx)
+}
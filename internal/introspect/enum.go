@@ -0,0 +1,83 @@
+package introspect
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+)
+
+type EnumValues []string
+
+func (values *EnumValues) Value() (driver.Value, error) {
+	return json.Marshal(values)
+}
+
+func (values *EnumValues) Scan(value interface{}) error {
+	if value == nil {
+		*values = nil
+
+		return nil
+	}
+
+	buffer, ok := value.([]byte)
+
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(buffer, values)
+}
+
+var enumColumnTypeRe = regexp.MustCompile(`(?i)^enum\((.*)\)$`)
+
+// ParseEnumValues extracts the labels of a MySQL column type such as
+// enum('draft','live'), in declaration order. Quotes may be escaped either
+// by doubling them or with a backslash.
+func ParseEnumValues(columnType string) EnumValues {
+	match := enumColumnTypeRe.FindStringSubmatch(strings.TrimSpace(columnType))
+
+	if match == nil {
+		return nil
+	}
+
+	values := make(EnumValues, 0)
+
+	var label strings.Builder
+
+	body := match[1]
+
+	quoted := false
+
+	for idx := 0; idx < len(body); idx++ {
+		ch := body[idx]
+
+		switch {
+		case !quoted && ch == '\'':
+			quoted = true
+
+			label.Reset()
+
+		case quoted && ch == '\\' && idx+1 < len(body):
+			idx++
+
+			label.WriteByte(body[idx])
+
+		case quoted && ch == '\'' && idx+1 < len(body) && body[idx+1] == '\'':
+			idx++
+
+			label.WriteByte('\'')
+
+		case quoted && ch == '\'':
+			quoted = false
+
+			values = append(values, label.String())
+
+		case quoted:
+			label.WriteByte(ch)
+		}
+	}
+
+	return values
+}
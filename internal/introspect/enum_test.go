@@ -0,0 +1,64 @@
+package introspect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseEnumValues(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		columnType string
+		want       EnumValues
+	}{
+		{
+			name:       "simple",
+			columnType: "enum('Released','Rumored','Post Production')",
+			want:       EnumValues{"Released", "Rumored", "Post Production"},
+		},
+		{
+			name:       "escaped quotes",
+			columnType: `enum('it''s','a\'b','c,d')`,
+			want:       EnumValues{"it's", "a'b", "c,d"},
+		},
+		{
+			name:       "empty label",
+			columnType: "ENUM('','x')",
+			want:       EnumValues{"", "x"},
+		},
+		{
+			name:       "not an enum",
+			columnType: "varchar(255)",
+			want:       nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := ParseEnumValues(testCase.columnType)
+
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
+func TestEnumValues_Scan(t *testing.T) {
+	t.Parallel()
+
+	values := EnumValues{}
+
+	err := values.Scan([]byte(`["G","PG"]`))
+
+	assert.Nil(t, err)
+
+	assert.Equal(t, EnumValues{"G", "PG"}, values)
+
+	err = values.Scan(nil)
+
+	assert.Nil(t, err)
+
+	assert.Nil(t, values)
+}
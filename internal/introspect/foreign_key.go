@@ -0,0 +1,74 @@
+package introspect
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mvoorberg/sqlxgen/internal/utils/array"
+)
+
+type ForeignKey struct {
+	ConstraintName string   `db:"constraint_name" json:"constraint_name"`
+	Columns        []string `db:"columns" json:"columns"`
+	RefSchemaName  string   `db:"ref_schema_name" json:"ref_schema_name"`
+	RefTableName   string   `db:"ref_table_name" json:"ref_table_name"`
+	RefColumns     []string `db:"ref_columns" json:"ref_columns"`
+	OnDelete       string   `db:"on_delete" json:"on_delete"`
+	OnUpdate       string   `db:"on_update" json:"on_update"`
+}
+
+func (fk *ForeignKey) String() string {
+	content := strings.Join(
+		[]string{
+			fmt.Sprintf("ConstraintName: %v", fk.ConstraintName),
+			fmt.Sprintf("Columns: %v", fk.Columns),
+			fmt.Sprintf("RefSchemaName: %v", fk.RefSchemaName),
+			fmt.Sprintf("RefTableName: %v", fk.RefTableName),
+			fmt.Sprintf("RefColumns: %v", fk.RefColumns),
+			fmt.Sprintf("OnDelete: %v", fk.OnDelete),
+			fmt.Sprintf("OnUpdate: %v", fk.OnUpdate),
+		},
+		", ",
+	)
+
+	return fmt.Sprintf("ForeignKey{%s}", content)
+}
+
+type ForeignKeys []ForeignKey
+
+func (fks *ForeignKeys) String() string {
+	content := strings.Join(
+		array.Map(
+			*fks,
+			func(fk ForeignKey, _ int) string {
+				return (&fk).String()
+			},
+		),
+		", ",
+	)
+
+	return fmt.Sprintf("[%s]", content)
+}
+
+func (fks *ForeignKeys) Value() (driver.Value, error) {
+	return json.Marshal(fks)
+}
+
+func (fks *ForeignKeys) Scan(value interface{}) error {
+	if value == nil {
+		*fks = make(ForeignKeys, 0)
+
+		return nil
+	}
+
+	buffer, ok := value.([]byte)
+
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(buffer, fks)
+}
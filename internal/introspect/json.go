@@ -0,0 +1,169 @@
+package introspect
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/joomcode/errorx"
+)
+
+// AnnotateJsonTypes applies the `-- column: x json_type: <go type>` comments
+// of a query to its result columns, the column is decoded into the Go type.
+// With a trailing generate, e.g. `json_type: []MovieSummary generate`, the
+// keys of the json_build_object of the column are kept to generate the type.
+func AnnotateJsonTypes(query string, columns []Column) ([]Column, error) {
+	re, err := regexp.Compile(`-{2,}\s*column:\s*(\w+)\s+json_type:\s*(\*?(?:\[\])*[\w./-]+)(\s+generate\b)?`)
+
+	if err != nil {
+		return nil, errorx.IllegalFormat.Wrap(err, "failed to compile json type regex")
+	}
+
+	annotated := make([]Column, len(columns))
+
+	copy(annotated, columns)
+
+	for _, match := range re.FindAllStringSubmatch(query, -1) {
+		columnName, goType, generate := match[1], match[2], match[3] != ""
+
+		switch strings.ToLower(goType) {
+		case "array", "object":
+			continue
+		}
+
+		for idx, column := range annotated {
+			if !strings.EqualFold(column.ColumnName, columnName) {
+				continue
+			}
+
+			annotated[idx].JsonType = goType
+
+			if !generate {
+				continue
+			}
+
+			if strings.Contains(goType, ".") {
+				return nil, errorx.IllegalArgument.New("unable to generate json type %s of column %s from another package", goType, columnName)
+			}
+
+			keys := jsonObjectKeys(query, column.ColumnName)
+
+			if len(keys) == 0 {
+				return nil, errorx.IllegalArgument.New("no json object keys found to generate json type %s of column %s", goType, columnName)
+			}
+
+			annotated[idx].JsonKeys = keys
+		}
+	}
+
+	return annotated, nil
+}
+
+var (
+	jsonObjectRe = regexp.MustCompile(`(?i)\b(?:jsonb?_build_object|json_object)\s*\(`)
+	commentRe    = regexp.MustCompile(`--[^\n]*`)
+)
+
+// jsonObjectKeys lists the literal keys of the first json object built in the
+// select item aliased as the column, e.g. 'id' and 'title' of
+// jsonb_agg(jsonb_build_object('id', m.id, 'title', m.title)) as "movies".
+func jsonObjectKeys(query string, columnName string) []string {
+	query = commentRe.ReplaceAllString(query, "")
+
+	aliasRe, err := regexp.Compile(`(?i)\bas\s+"?` + regexp.QuoteMeta(columnName) + `"?(\W|$)`)
+
+	if err != nil {
+		return nil
+	}
+
+	loc := aliasRe.FindStringIndex(query)
+
+	if loc == nil {
+		return nil
+	}
+
+	item := query[selectItemStart(query, loc[0]):loc[0]]
+
+	objLoc := jsonObjectRe.FindStringIndex(item)
+
+	if objLoc == nil {
+		return nil
+	}
+
+	keys := make([]string, 0)
+
+	for idx, arg := range splitArgs(item[objLoc[1]:]) {
+		arg = strings.TrimSpace(arg)
+
+		if idx%2 != 0 || len(arg) < 2 || arg[0] != '\'' || arg[len(arg)-1] != '\'' {
+			continue
+		}
+
+		keys = append(keys, arg[1:len(arg)-1])
+	}
+
+	return keys
+}
+
+// selectItemStart walks back from the end of a select item to the comma, or
+// the select keyword, it starts after.
+func selectItemStart(query string, end int) int {
+	depth := 0
+
+	for idx := end - 1; idx >= 0; idx-- {
+		switch query[idx] {
+		case ')':
+			depth++
+
+		case '(':
+			if depth == 0 {
+				return idx + 1
+			}
+
+			depth--
+
+		case ',':
+			if depth == 0 {
+				return idx + 1
+			}
+		}
+
+		if depth == 0 && strings.HasSuffix(strings.ToLower(query[:idx]), "select") {
+			return idx
+		}
+	}
+
+	return 0
+}
+
+// splitArgs splits the arguments of a call, up to its closing paren, on the
+// commas outside of nested parens and quotes.
+func splitArgs(args string) []string {
+	parts := make([]string, 0)
+
+	depth, start, quoted := 0, 0, false
+
+	for idx := 0; idx < len(args); idx++ {
+		switch {
+		case args[idx] == '\'':
+			quoted = !quoted
+
+		case quoted:
+
+		case args[idx] == '(':
+			depth++
+
+		case args[idx] == ')' && depth == 0:
+			return append(parts, args[start:idx])
+
+		case args[idx] == ')':
+			depth--
+
+		case args[idx] == ',' && depth == 0:
+			parts = append(parts, args[start:idx])
+
+			start = idx + 1
+		}
+	}
+
+	return parts
+}
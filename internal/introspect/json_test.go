@@ -0,0 +1,88 @@
+package introspect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnnotateJsonTypes(t *testing.T) {
+	t.Parallel()
+
+	//language=PostgreSQL
+	query := `
+select
+a.id as "id",
+a.settings as "settings", -- column: settings json_type: example.com/types.Settings
+coalesce(
+  jsonb_agg(jsonb_build_object('id', m.id, 'title', upper(m.title), 'release_date', m.release_date)),
+  '[]'
+) as "movies", -- column: movies json_type: []MovieSummary generate
+a.tags as "tags" -- column: tags json_type: array
+from actors a
+left join movies m on m.actor_id = a.id
+group by a.id;
+`
+
+	columns := []Column{
+		{ColumnName: "id", Type: "int8"},
+		{ColumnName: "settings", Type: "jsonb"},
+		{ColumnName: "movies", Type: "jsonb"},
+		{ColumnName: "tags", Type: "jsonb"},
+	}
+
+	got, err := AnnotateJsonTypes(query, columns)
+
+	assert.Nil(t, err)
+
+	assert.Equal(
+		t,
+		[]Column{
+			{ColumnName: "id", Type: "int8"},
+			{ColumnName: "settings", Type: "jsonb", JsonType: "example.com/types.Settings"},
+			{
+				ColumnName: "movies",
+				Type:       "jsonb",
+				JsonType:   "[]MovieSummary",
+				JsonKeys:   []string{"id", "title", "release_date"},
+			},
+			{ColumnName: "tags", Type: "jsonb"},
+		},
+		got,
+	)
+
+	assert.Equal(t, "", columns[1].JsonType)
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		testCases := []struct {
+			name  string
+			query string
+			err   string
+		}{
+			{
+				name:  "another package",
+				query: `select a.settings as "settings" -- column: settings json_type: example.com/types.Settings generate`,
+				err:   "from another package",
+			},
+			{
+				name:  "no object",
+				query: `select a.settings as "settings" -- column: settings json_type: Settings generate`,
+				err:   "no json object keys found",
+			},
+		}
+
+		for _, testCase := range testCases {
+			testCase := testCase
+
+			t.Run(testCase.name, func(t *testing.T) {
+				t.Parallel()
+
+				_, err := AnnotateJsonTypes(testCase.query, []Column{{ColumnName: "settings"}})
+
+				assert.ErrorContains(t, err, testCase.err)
+			})
+		}
+	})
+}
@@ -52,6 +52,12 @@ func (s source) IntrospectSchema(tx *sqlx.Tx) ([]i.Table, error) {
 				continue
 			}
 
+			for idx, column := range table.Columns {
+				if column.Type == "enum" {
+					table.Columns[idx].EnumValues = i.ParseEnumValues(column.ColumnType)
+				}
+			}
+
 			slices.SortStableFunc(
 				table.Columns,
 				func(a, b i.Column) int {
@@ -65,6 +71,13 @@ func (s source) IntrospectSchema(tx *sqlx.Tx) ([]i.Table, error) {
 				},
 			)
 
+			slices.SortStableFunc(
+				table.UniqueKeys,
+				func(a, b i.UniqueKey) int {
+					return cmp.Compare(a.ConstraintName, b.ConstraintName)
+				},
+			)
+
 			tables = append(tables, table)
 		}
 	}
@@ -11,9 +11,74 @@ json_arrayagg(
     'is_sequence', c.extra like '%auto_increment%',
     'generated', c.generation_expression != '',
     'pk_name', coalesce(kc.constraint_name, ''),
-    'pk_ordinal_position', coalesce(kc.ordinal_position, 0)
+    'pk_ordinal_position', coalesce(kc.ordinal_position, 0),
+    'column_type', c.column_type
   )
-) as columns
+) as columns,
+coalesce(
+  (
+    select
+    json_arrayagg(
+      json_object(
+        'constraint_name', fk.constraint_name,
+        'columns', fk.columns,
+        'ref_schema_name', fk.ref_schema_name,
+        'ref_table_name', fk.ref_table_name,
+        'ref_columns', fk.ref_columns,
+        'on_delete', fk.on_delete,
+        'on_update', fk.on_update
+      )
+    )
+    from (
+      select
+      rc.constraint_name as constraint_name,
+      cast(concat('[', group_concat(json_quote(fkc.column_name) order by fkc.ordinal_position), ']') as json) as columns,
+      rc.unique_constraint_schema as ref_schema_name,
+      rc.referenced_table_name as ref_table_name,
+      cast(concat('[', group_concat(json_quote(fkc.referenced_column_name) order by fkc.ordinal_position), ']') as json) as ref_columns,
+      rc.delete_rule as on_delete,
+      rc.update_rule as on_update
+      from information_schema.referential_constraints rc
+      inner join information_schema.key_column_usage fkc on (
+        true
+        and fkc.constraint_schema = rc.constraint_schema
+        and fkc.constraint_name = rc.constraint_name
+        and fkc.table_name = rc.table_name
+      )
+      where true
+      and rc.constraint_schema = c.table_schema
+      and rc.table_name = c.table_name
+      group by rc.constraint_name, rc.unique_constraint_schema, rc.referenced_table_name, rc.delete_rule, rc.update_rule
+    ) fk
+  ),
+  json_array()
+) as foreign_keys,
+coalesce(
+  (
+    select
+    json_arrayagg(
+      json_object(
+        'constraint_name', uk.constraint_name,
+        'columns', uk.columns
+      )
+    )
+    from (
+      select
+      s.index_name as constraint_name,
+      cast(concat('[', group_concat(json_quote(s.column_name) order by s.seq_in_index), ']') as json) as columns
+      from information_schema.statistics s
+      where true
+      and s.table_schema = c.table_schema
+      and s.table_name = c.table_name
+      and s.non_unique = 0
+      and s.index_name != 'PRIMARY'
+      group by s.index_name
+      -- functional key parts have no column and can't be targeted
+      having count(s.column_name) = count(*)
+    ) uk
+  ),
+  json_array()
+) as unique_keys
 from information_schema.columns c
 left join information_schema.KEY_COLUMN_USAGE kc on (
   true
@@ -73,7 +73,7 @@ func TestIntrospectSchema(t *testing.T) {
 		ExpectQuery("select (.+) from information_schema.columns c (.+) where").
 		WithArgs("public").
 		WillReturnRows(
-			sqlmock.NewRows([]string{"schema_name", "table_name", "columns"}).
+			sqlmock.NewRows([]string{"schema_name", "table_name", "columns", "foreign_keys", "unique_keys"}).
 				FromCSVString(introspectSchemaResultCsv),
 		)
 
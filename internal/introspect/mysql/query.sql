@@ -9,7 +9,8 @@ c.data_type as type,
 false as is_array,
 false as is_sequence,
 c.is_nullable = 'YES' as nullable,
-c.generation_expression != '' as "generated"
+c.generation_expression != '' as "generated",
+c.column_type as column_type
 from information_schema.columns c
 left join information_schema.key_column_usage kc on (
   true
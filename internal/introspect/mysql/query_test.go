@@ -77,7 +77,8 @@ c.data_type as type,
 false as is_array,
 false as is_sequence,
 c.is_nullable = 'YES' as nullable,
-c.generation_expression != '' as "generated"
+c.generation_expression != '' as "generated",
+c.column_type as column_type
 from information_schema.columns c
 left join information_schema.key_column_usage kc on (
   true
@@ -114,7 +115,8 @@ c.data_type as type,
 false as is_array,
 false as is_sequence,
 c.is_nullable = 'YES' as nullable,
-c.generation_expression != '' as "generated"
+c.generation_expression != '' as "generated",
+c.column_type as column_type
 from information_schema.columns c
 left join information_schema.key_column_usage kc on (
   true
@@ -206,7 +208,8 @@ c.data_type as type,
 false as is_array,
 false as is_sequence,
 c.is_nullable = 'YES' as nullable,
-c.generation_expression != '' as "generated"
+c.generation_expression != '' as "generated",
+c.column_type as column_type
 from information_schema.columns c
 left join information_schema.key_column_usage kc on (
   true
@@ -548,7 +551,8 @@ c.data_type as type,
 false as is_array,
 false as is_sequence,
 c.is_nullable = 'YES' as nullable,
-c.generation_expression != '' as "generated"
+c.generation_expression != '' as "generated",
+c.column_type as column_type
 from information_schema.columns c
 left join information_schema.key_column_usage kc on (
   true
@@ -652,7 +656,8 @@ c.data_type as type,
 false as is_array,
 false as is_sequence,
 c.is_nullable = 'YES' as nullable,
-c.generation_expression != '' as "generated"
+c.generation_expression != '' as "generated",
+c.column_type as column_type
 from information_schema.columns c
 left join information_schema.key_column_usage kc on (
   true
@@ -922,6 +927,83 @@ func TestIntrospectQueries(t *testing.T) {
 	}
 }
 
+func TestIntrospectQueries_Dirs(t *testing.T) {
+	t.Parallel()
+
+	// the fake discovery finds the same file in every query dir
+	fd := fs.NewFakeFileDiscovery(
+		[]fs.FakeDiscover{
+			{
+				Content:  getActorQuery,
+				Dir:      "fixtures",
+				Filename: "get-actor.sql",
+				FullPath: "fixtures/get-actor.sql",
+			},
+		},
+	)
+
+	s := NewIntrospect(fd, IntrospectArgs{QueryDirs: []string{"actors", "movies"}})
+
+	db, mock, err := utils.NewMockSqlx()
+
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+
+	defer func(db *sqlx.DB) {
+		err := db.Close()
+
+		if err != nil {
+			t.Fatalf("failed to close mock db: %v", err)
+		}
+	}(db)
+
+	mock.ExpectBegin()
+
+	// once per query dir
+	for range []string{"actors", "movies"} {
+		mock.ExpectExec("create table if not exists sample_query_introspection (.+)").
+			WillReturnResult(
+				sqlmock.NewResult(0, 0),
+			)
+
+		mock.ExpectQuery("select (.+) from information_schema.columns c (.+)").
+			WillReturnRows(
+				sqlmock.NewRows([]string{"column_name", "type", "type_id", "is_array", "is_sequence", "nullable", "generated"}).
+					FromCSVString(getActorResultCsv),
+			)
+
+		mock.ExpectExec("drop table if exists sample_query_introspection").
+			WillReturnResult(
+				sqlmock.NewResult(0, 0),
+			)
+	}
+
+	mock.ExpectRollback()
+
+	mock.ExpectClose()
+
+	tx, err := db.Beginx()
+
+	if err != nil {
+		t.Fatalf("failed to create transaction: %v", err)
+	}
+
+	defer func(tx *sqlx.Tx) {
+		err := tx.Rollback()
+
+		if err != nil {
+			t.Fatalf("failed to rollback transaction: %v", err)
+		}
+	}(tx)
+
+	queries, err := s.IntrospectQueries(tx)
+
+	assert.NoError(t, err)
+
+	assert.Len(t, queries, 2)
+}
+
 //go:embed fixtures/list-actors.sql
 var listActorsQuery string
 
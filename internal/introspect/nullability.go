@@ -0,0 +1,36 @@
+package introspect
+
+import (
+	"regexp"
+
+	"github.com/joomcode/errorx"
+)
+
+// AnnotateNullability applies the `-- column: x not_null` and
+// `-- column: x nullable` comments of a query to its result columns, they win
+// over the nullability introspected.
+func AnnotateNullability(query string, columns []Column) ([]Column, error) {
+	re, err := regexp.Compile(`-{2,}\s*column:\s*(\w+)\s+(not_null|nullable)\b`)
+
+	if err != nil {
+		return nil, errorx.IllegalFormat.Wrap(err, "failed to compile nullability regex")
+	}
+
+	nullable := make(map[string]bool)
+
+	for _, match := range re.FindAllStringSubmatch(query, -1) {
+		nullable[match[1]] = match[2] == "nullable"
+	}
+
+	annotated := make([]Column, len(columns))
+
+	for idx, column := range columns {
+		if value, ok := nullable[column.ColumnName]; ok {
+			column.Nullable = value
+		}
+
+		annotated[idx] = column
+	}
+
+	return annotated, nil
+}
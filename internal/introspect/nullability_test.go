@@ -0,0 +1,43 @@
+package introspect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnnotateNullability(t *testing.T) {
+	t.Parallel()
+
+	//language=PostgreSQL
+	query := `
+select
+a.id as "id", -- column: id nullable
+a.name as "name", -- column: name not_null
+upper(a.name) as "upperName" -- column: upperName not_null
+from actors a
+left join movies m on m.id = a.movie_id;
+`
+
+	columns := []Column{
+		{ColumnName: "id", Nullable: false},
+		{ColumnName: "name", Nullable: true},
+		{ColumnName: "upperName", Nullable: true},
+		{ColumnName: "title", Nullable: true},
+	}
+
+	got, err := AnnotateNullability(query, columns)
+
+	assert.Nil(t, err)
+
+	assert.Equal(
+		t,
+		[]Column{
+			{ColumnName: "id", Nullable: true},
+			{ColumnName: "name", Nullable: false},
+			{ColumnName: "upperName", Nullable: false},
+			{ColumnName: "title", Nullable: true},
+		},
+		got,
+	)
+}
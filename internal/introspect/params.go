@@ -4,13 +4,20 @@ import (
 	"cmp"
 	"regexp"
 	"slices"
+	"strings"
 
+	"github.com/jmoiron/sqlx"
 	"github.com/joomcode/errorx"
 	"github.com/mvoorberg/sqlxgen/internal/utils"
 )
 
+// ParseParams lists the named params of a query, typed after their
+// `-- :name type: x` comment or as any. Types can span several words, like
+// timestamp with time zone, and end with [] for arrays.
 func ParseParams(query string) ([]Column, error) {
-	re1, err := regexp.Compile(`\s*:(\w+)\s+type:\s+(\w+),?\s*`)
+	re1, err := regexp.Compile(
+		`\s*:(\w+)\s+type:\s+(\w+(?:[ \t]+(?:with|without|time|zone|precision|varying))*(?:\[\])?),?\s*`,
+	)
 
 	if err != nil {
 		return nil, errorx.IllegalFormat.Wrap(err, "failed to compile params type regex")
@@ -52,7 +59,9 @@ func ParseParams(query string) ([]Column, error) {
 		}
 
 		if columnType, ok := typeMap[columnName]; ok {
-			column.Type = columnType
+			column.Type = strings.Join(strings.Fields(strings.TrimSuffix(columnType, "[]")), " ")
+
+			column.IsArray = strings.HasSuffix(columnType, "[]")
 		}
 
 		uniqueParams[columnName] = column
@@ -85,3 +94,110 @@ func ParseParamsAsNil(query string) (map[string]any, error) {
 
 	return params, nil
 }
+
+// PositionalParams rewrites the named params of a query into the $n params
+// of postgres and lists the param name of every position, a name used twice
+// takes two positions. Comments are dropped as the params they mention are
+// not part of the query.
+func PositionalParams(query string) (string, []string, error) {
+	query = strings.TrimSuffix(strings.TrimSpace(stripComments(query)), ";")
+
+	names, err := ParseParamsAsNil(query)
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	for name := range names {
+		names[name] = name
+	}
+
+	positional, args, err := sqlx.BindNamed(sqlx.DOLLAR, query, names)
+
+	if err != nil {
+		return "", nil, errorx.IllegalFormat.Wrap(err, "failed to bind named params")
+	}
+
+	positions := make([]string, len(args))
+
+	for idx, arg := range args {
+		positions[idx], _ = arg.(string)
+	}
+
+	return positional, positions, nil
+}
+
+var dollarTagRe = regexp.MustCompile(`^\$(?:[A-Za-z_][A-Za-z0-9_]*)?\$`)
+
+// stripComments drops the -- comments of a query up to the end of their line,
+// and keeps the quoted strings, identifiers and dollar quoted bodies holding a
+// -- as they are.
+func stripComments(query string) string {
+	var stripped strings.Builder
+
+	for idx := 0; idx < len(query); {
+		switch {
+		case strings.HasPrefix(query[idx:], "--"):
+			end := strings.IndexByte(query[idx:], '\n')
+
+			if end < 0 {
+				return stripped.String()
+			}
+
+			idx += end
+
+		case query[idx] == '\'' || query[idx] == '"':
+			end := quotedEnd(query, idx)
+
+			stripped.WriteString(query[idx:end])
+
+			idx = end
+
+		case query[idx] == '$' && dollarTagRe.MatchString(query[idx:]):
+			tag := dollarTagRe.FindString(query[idx:])
+
+			end := strings.Index(query[idx+len(tag):], tag)
+
+			if end < 0 {
+				end = len(query)
+			} else {
+				end += idx + 2*len(tag)
+			}
+
+			stripped.WriteString(query[idx:end])
+
+			idx = end
+
+		default:
+			stripped.WriteByte(query[idx])
+
+			idx++
+		}
+	}
+
+	return stripped.String()
+}
+
+// quotedEnd is the index following the quote closing the string or identifier
+// opened at start. A doubled quote is part of the string, and so is a quote
+// escaped by a backslash in an E'...' string.
+func quotedEnd(query string, start int) int {
+	quote := query[start]
+
+	escapes := quote == '\'' && start > 0 && (query[start-1] == 'E' || query[start-1] == 'e')
+
+	for idx := start + 1; idx < len(query); idx++ {
+		switch {
+		case escapes && query[idx] == '\\':
+			idx++
+
+		case query[idx] == quote && idx+1 < len(query) && query[idx+1] == quote:
+			idx++
+
+		case query[idx] == quote:
+			return idx + 1
+		}
+	}
+
+	return len(query)
+}
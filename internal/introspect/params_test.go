@@ -186,6 +186,60 @@ offset :offset; -- :offset type: int
 				},
 			},
 		},
+		{
+			name: "should parse multi word and array types",
+			args: args{
+				query: `
+select * from movies m
+where m.release_date > :after -- :after type: timestamp with time zone
+and m.status = any(:statuses) -- :statuses type: text[]
+and m.title = :title; -- :title type: character   varying
+`,
+			},
+			want: []Column{
+				{
+					ColumnName: "after",
+					Type:       "timestamp with time zone",
+					TypeId:     "0",
+					Nullable:   true,
+					JsonType:   "identity",
+				},
+				{
+					ColumnName: "statuses",
+					Type:       "text",
+					TypeId:     "0",
+					IsArray:    true,
+					Nullable:   true,
+					JsonType:   "identity",
+				},
+				{
+					ColumnName: "title",
+					Type:       "character varying",
+					TypeId:     "0",
+					Nullable:   true,
+					JsonType:   "identity",
+				},
+			},
+		},
+		{
+			name: "should not parse the next line into the type",
+			args: args{
+				query: `
+-- :since type: timestamp
+with recent as (select * from movies m where m.release_date > :since)
+select * from recent;
+`,
+			},
+			want: []Column{
+				{
+					ColumnName: "since",
+					Type:       "timestamp",
+					TypeId:     "0",
+					Nullable:   true,
+					JsonType:   "identity",
+				},
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -306,3 +360,59 @@ offset :offset; -- :offset type: int
 		})
 	}
 }
+
+func TestPositionalParams(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		query string
+		want  string
+		names []string
+	}{
+		{
+			name:  "no params",
+			query: `select * from users;`,
+			want:  `select * from users`,
+			names: []string{},
+		},
+		{
+			name: "commented and repeated params",
+			query: `select * from users
+where id = :id -- :id type: int
+and (cast(:search as text) is null or name ilike :search);`,
+			want: `select * from users
+where id = $1 
+and (cast($2 as text) is null or name ilike $3)`,
+			names: []string{"id", "search", "search"},
+		},
+		{
+			name: "dashes in literals",
+			query: `select * from movies -- movies
+where title like '%--%' and tagline <> 'it''s -- over'
+and overview <> E'it\'s -- over' and "a--b" = $body$ -- $body$
+and id = :id; -- :id type: int`,
+			want: `select * from movies 
+where title like '%--%' and tagline <> 'it''s -- over'
+and overview <> E'it\'s -- over' and "a--b" = $body$ -- $body$
+and id = $1`,
+			names: []string{"id"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, names, err := PositionalParams(testCase.query)
+
+			assert.Nil(t, err)
+
+			assert.Equal(t, testCase.want, got)
+
+			assert.Equal(t, testCase.names, names)
+		})
+	}
+}
@@ -11,9 +11,82 @@ json_agg(
     'nullable', not attr.attnotnull,
     'generated', attr.attgenerated = 's',
     'pk_name', coalesce(kcu.constraint_name, ''),
-    'pk_ordinal_position', coalesce(kcu.ordinal_position, 0)
+    'pk_ordinal_position', coalesce(kcu.ordinal_position, 0),
+    'enum_values', (
+      select json_agg(e.enumlabel order by e.enumsortorder)
+      from pg_catalog.pg_enum e
+      where e.enumtypid = tp.oid
+    )
   ) order by kcu.ordinal_position, attr.attname
-) as columns
+) as columns,
+coalesce(
+  (
+    select
+    json_agg(
+      json_build_object(
+        'constraint_name', con.conname,
+        'columns', (
+          select json_agg(fka.attname order by fkc.ordinality)
+          from unnest(con.conkey) with ordinality fkc(attnum, ordinality)
+          inner join pg_catalog.pg_attribute fka on fka.attrelid = con.conrelid and fka.attnum = fkc.attnum
+        ),
+        'ref_schema_name', rns.nspname,
+        'ref_table_name', rcls.relname,
+        'ref_columns', (
+          select json_agg(rfa.attname order by rfc.ordinality)
+          from unnest(con.confkey) with ordinality rfc(attnum, ordinality)
+          inner join pg_catalog.pg_attribute rfa on rfa.attrelid = con.confrelid and rfa.attnum = rfc.attnum
+        ),
+        'on_delete', case con.confdeltype
+          when 'r' then 'RESTRICT'
+          when 'c' then 'CASCADE'
+          when 'n' then 'SET NULL'
+          when 'd' then 'SET DEFAULT'
+          else 'NO ACTION'
+        end,
+        'on_update', case con.confupdtype
+          when 'r' then 'RESTRICT'
+          when 'c' then 'CASCADE'
+          when 'n' then 'SET NULL'
+          when 'd' then 'SET DEFAULT'
+          else 'NO ACTION'
+        end
+      ) order by con.conname
+    )
+    from pg_catalog.pg_constraint con
+    inner join pg_catalog.pg_class rcls on rcls.oid = con.confrelid
+    inner join pg_catalog.pg_namespace rns on rns.oid = rcls.relnamespace
+    where true
+    and con.conrelid = cls.oid
+    and con.contype = 'f'
+  ),
+  '[]'
+) as foreign_keys,
+coalesce(
+  (
+    select
+    json_agg(
+      json_build_object(
+        'constraint_name', icls.relname,
+        'columns', (
+          select json_agg(uka.attname order by ukc.ordinality)
+          from unnest(idx.indkey::int2[]) with ordinality ukc(attnum, ordinality)
+          inner join pg_catalog.pg_attribute uka on uka.attrelid = idx.indrelid and uka.attnum = ukc.attnum
+        )
+      ) order by icls.relname
+    )
+    from pg_catalog.pg_index idx
+    inner join pg_catalog.pg_class icls on icls.oid = idx.indexrelid
+    where true
+    and idx.indrelid = cls.oid
+    and idx.indisunique
+    and not idx.indisprimary
+    -- partial and expression indexes can't be targeted by their columns alone
+    and idx.indpred is null
+    and not (0 = any(idx.indkey::int2[]))
+  ),
+  '[]'
+) as unique_keys
 from pg_catalog.pg_attribute attr
 inner join pg_catalog.pg_class cls on cls.oid = attr.attrelid
 inner join pg_catalog.pg_namespace ns on ns.oid = cls.relnamespace
@@ -43,5 +116,5 @@ where true
 and ns.nspname = :schema
 and cls.relkind in ('r', 't', 'v', 'm', 'p')
 and attr.attnum >= 1
-group by ns.nspname, cls.relname
+group by ns.nspname, cls.relname, cls.oid
 order by cls.relname;
\ No newline at end of file
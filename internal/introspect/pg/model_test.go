@@ -73,7 +73,7 @@ func TestIntrospectSchema(t *testing.T) {
 		ExpectQuery("select (.+) from pg_catalog.pg_attribute attr (.+) where").
 		WithArgs("public").
 		WillReturnRows(
-			sqlmock.NewRows([]string{"schema_name", "table_name", "columns"}).
+			sqlmock.NewRows([]string{"schema_name", "table_name", "columns", "foreign_keys", "unique_keys"}).
 				FromCSVString(introspectSchemaResultCsv),
 		)
 
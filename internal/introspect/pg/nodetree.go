@@ -0,0 +1,291 @@
+package pg
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/joomcode/errorx"
+)
+
+// treeNode is a node of a pg_node_tree, the text form postgres stores the
+// parsed query of a view in, e.g. {VAR :varno 1 :varattno 2}.
+type treeNode struct {
+	Name   string
+	Fields map[string]treeValue
+}
+
+// treeValue is either a node, a list of values or a single token, <> when
+// null.
+type treeValue struct {
+	Node  *treeNode
+	List  []treeValue
+	Token string
+}
+
+func (v treeValue) field(name string) treeValue {
+	if v.Node == nil {
+		return treeValue{}
+	}
+
+	return v.Node.Fields[name]
+}
+
+func (v treeValue) is(name string) bool {
+	return v.Node != nil && v.Node.Name == name
+}
+
+// walk calls fn on the value and every node nested in it.
+func (v treeValue) walk(fn func(node *treeNode)) {
+	if v.Node != nil {
+		fn(v.Node)
+
+		for _, field := range v.Node.Fields {
+			field.walk(fn)
+		}
+	}
+
+	for _, item := range v.List {
+		item.walk(fn)
+	}
+}
+
+type treeParser struct {
+	tokens []string
+	pos    int
+}
+
+// parseNodeTree parses the text of a pg_node_tree, it follows pg_strtok of
+// postgres: parens and braces are tokens of their own, everything else is
+// split on whitespace and a backslash escapes the next character.
+func parseNodeTree(text string) (treeValue, error) {
+	p := treeParser{tokens: tokenizeNodeTree(text)}
+
+	value, err := p.value()
+
+	if err != nil {
+		return treeValue{}, err
+	}
+
+	if p.pos != len(p.tokens) {
+		return treeValue{}, errorx.IllegalFormat.New("unexpected token %s in node tree", p.tokens[p.pos])
+	}
+
+	return value, nil
+}
+
+func tokenizeNodeTree(text string) []string {
+	tokens := make([]string, 0)
+
+	runes := []rune(text)
+
+	for idx := 0; idx < len(runes); {
+		r := runes[idx]
+
+		if unicode.IsSpace(r) {
+			idx++
+
+			continue
+		}
+
+		if strings.ContainsRune("(){}", r) {
+			tokens = append(tokens, string(r))
+
+			idx++
+
+			continue
+		}
+
+		var token strings.Builder
+
+		for idx < len(runes) && !unicode.IsSpace(runes[idx]) && !strings.ContainsRune("(){}", runes[idx]) {
+			if runes[idx] == '\\' && idx+1 < len(runes) {
+				idx++
+			}
+
+			token.WriteRune(runes[idx])
+
+			idx++
+		}
+
+		tokens = append(tokens, token.String())
+	}
+
+	return tokens
+}
+
+func (p *treeParser) next() (string, error) {
+	if p.pos >= len(p.tokens) {
+		return "", errorx.IllegalFormat.New("unexpected end of node tree")
+	}
+
+	token := p.tokens[p.pos]
+
+	p.pos++
+
+	return token, nil
+}
+
+func (p *treeParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+
+	return p.tokens[p.pos]
+}
+
+func (p *treeParser) value() (treeValue, error) {
+	token, err := p.next()
+
+	if err != nil {
+		return treeValue{}, err
+	}
+
+	switch token {
+	case "{":
+		return p.node()
+
+	case "(":
+		list := make([]treeValue, 0)
+
+		for p.peek() != ")" {
+			item, err := p.value()
+
+			if err != nil {
+				return treeValue{}, err
+			}
+
+			list = append(list, item)
+		}
+
+		p.pos++
+
+		return treeValue{List: list}, nil
+
+	case ")", "}":
+		return treeValue{}, errorx.IllegalFormat.New("unexpected token %s in node tree", token)
+	}
+
+	return treeValue{Token: token}, nil
+}
+
+// node parses the fields of a node, a field is a :name token followed by its
+// value, extra tokens of a value like the bytes of a datum are skipped.
+func (p *treeParser) node() (treeValue, error) {
+	name, err := p.next()
+
+	if err != nil {
+		return treeValue{}, err
+	}
+
+	node := &treeNode{Name: name, Fields: make(map[string]treeValue)}
+
+	for {
+		token, err := p.next()
+
+		if err != nil {
+			return treeValue{}, err
+		}
+
+		if token == "}" {
+			return treeValue{Node: node}, nil
+		}
+
+		if !strings.HasPrefix(token, ":") {
+			continue
+		}
+
+		if next := p.peek(); next == "}" || strings.HasPrefix(next, ":") {
+			node.Fields[token[1:]] = treeValue{}
+
+			continue
+		}
+
+		value, err := p.value()
+
+		if err != nil {
+			return treeValue{}, err
+		}
+
+		node.Fields[token[1:]] = value
+
+		for next := p.peek(); next != "}" && next != "" && !strings.HasPrefix(next, ":"); next = p.peek() {
+			_, err := p.value()
+
+			if err != nil {
+				return treeValue{}, err
+			}
+		}
+	}
+}
+
+// columnOrigin is the table column a result column is read from.
+type columnOrigin struct {
+	TableId  string `db:"table_id"`
+	ColumnId string `db:"column_id"`
+}
+
+// resultOrigins maps the result columns of a view to the table column they
+// are read from as is. Columns computed from an expression, read from a
+// subquery or from the nullable side of an outer join are left out, their
+// nullability doesn't follow the one of a table column.
+func resultOrigins(evAction string) (map[string]columnOrigin, error) {
+	tree, err := parseNodeTree(evAction)
+
+	if err != nil {
+		return nil, errorx.Decorate(err, "failed to parse view query")
+	}
+
+	if len(tree.List) != 1 || !tree.List[0].is("QUERY") {
+		return nil, errorx.IllegalFormat.New("view query is not a single query")
+	}
+
+	query := tree.List[0]
+
+	rtable := query.field("rtable").List
+
+	// before postgres 16 vars don't tell when an outer join can null them
+	outerJoin := false
+
+	query.field("jointree").walk(func(node *treeNode) {
+		if node.Name == "JOINEXPR" && node.Fields["jointype"].Token != "0" {
+			outerJoin = true
+		}
+	})
+
+	origins := make(map[string]columnOrigin)
+
+	for _, entry := range query.field("targetList").List {
+		expr := entry.field("expr")
+
+		if entry.field("resjunk").Token != "false" || !expr.is("VAR") {
+			continue
+		}
+
+		tableId, columnId := entry.field("resorigtbl").Token, entry.field("resorigcol").Token
+
+		if tableId == "" || tableId == "0" || columnId == "" || columnId == "0" {
+			continue
+		}
+
+		if expr.field("varlevelsup").Token != "0" {
+			continue
+		}
+
+		nullingRels, ok := expr.Node.Fields["varnullingrels"]
+
+		if ok && len(nullingRels.List) > 1 || !ok && outerJoin {
+			continue
+		}
+
+		varNo, err := strconv.Atoi(expr.field("varno").Token)
+
+		if err != nil || varNo < 1 || varNo > len(rtable) || rtable[varNo-1].field("rtekind").Token != "0" {
+			continue
+		}
+
+		origins[entry.field("resname").Token] = columnOrigin{TableId: tableId, ColumnId: columnId}
+	}
+
+	return origins, nil
+}
@@ -0,0 +1,164 @@
+package pg
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/joomcode/errorx"
+	"github.com/lib/pq"
+	i "github.com/mvoorberg/sqlxgen/internal/introspect"
+)
+
+// traceNullability marks the result columns read as is from a not null table
+// column as not null. The columns of the temp table the query is introspected
+// with are always nullable, so the query is created as a temp view within a
+// savepoint and its parsed query tells where every result column comes from.
+// A query postgres can't create a view of keeps its columns nullable.
+func traceNullability(tx *sqlx.Tx, args QueryArgs, params []i.Column, columns []i.Column) ([]i.Column, error) {
+	viewSql, err := viewQuery(args.Query, params)
+
+	if err != nil {
+		msg := msgWithFilename(args.source(), "failed to generate view query")
+
+		return nil, errorx.Decorate(err, msg)
+	}
+
+	_, err = tx.Exec("savepoint sqlxgen_nullability")
+
+	if err != nil {
+		msg := msgWithFilename(args.source(), "failed to create nullability savepoint")
+
+		return nil, errorx.InternalError.Wrap(err, msg)
+	}
+
+	origins, traceErr := viewOrigins(tx, viewSql)
+
+	// the view is only needed for its parsed query
+	_, err = tx.Exec("rollback to savepoint sqlxgen_nullability")
+
+	if err != nil {
+		msg := msgWithFilename(args.source(), "failed to rollback nullability savepoint")
+
+		return nil, errorx.InternalError.Wrap(err, msg)
+	}
+
+	_, err = tx.Exec("release savepoint sqlxgen_nullability")
+
+	if err != nil {
+		msg := msgWithFilename(args.source(), "failed to release nullability savepoint")
+
+		return nil, errorx.InternalError.Wrap(err, msg)
+	}
+
+	if traceErr != nil {
+		slog.Warn("unable to trace query result nullability", "query", args.source(), "error", traceErr)
+
+		return columns, nil
+	}
+
+	if len(origins) == 0 {
+		return columns, nil
+	}
+
+	tableIds := make([]string, 0)
+
+	for _, origin := range origins {
+		if !slices.Contains(tableIds, origin.TableId) {
+			tableIds = append(tableIds, origin.TableId)
+		}
+	}
+
+	notNullColumns := make([]columnOrigin, 0)
+
+	err = tx.Select(
+		&notNullColumns,
+		`select attrelid as table_id, attnum as column_id from pg_attribute where attnotnull and attrelid = any(cast($1 as oid[]))`,
+		pq.Array(tableIds),
+	)
+
+	if err != nil {
+		msg := msgWithFilename(args.source(), "failed to select not null columns")
+
+		return nil, errorx.InternalError.Wrap(err, msg)
+	}
+
+	traced := make([]i.Column, len(columns))
+
+	for idx, column := range columns {
+		if origin, ok := origins[column.ColumnName]; ok {
+			column.Nullable = !slices.Contains(notNullColumns, origin)
+		}
+
+		traced[idx] = column
+	}
+
+	return traced, nil
+}
+
+func viewOrigins(tx *sqlx.Tx, viewSql string) (map[string]columnOrigin, error) {
+	_, err := tx.Exec(fmt.Sprintf("create temp view sample_query_introspection_view as %s", viewSql))
+
+	if err != nil {
+		return nil, errorx.InternalError.Wrap(err, "failed to create view")
+	}
+
+	var evAction string
+
+	err = tx.Get(
+		&evAction,
+		`select cast(r.ev_action as text) from pg_rewrite r where r.ev_class = cast('sample_query_introspection_view' as regclass)`,
+	)
+
+	if err != nil {
+		return nil, errorx.InternalError.Wrap(err, "failed to select view query")
+	}
+
+	return resultOrigins(evAction)
+}
+
+// viewQuery inlines the params of a query as typed nulls, a view can't have
+// params.
+func viewQuery(query string, params []i.Column) (string, error) {
+	positional, names, err := i.PositionalParams(query)
+
+	if err != nil {
+		return "", err
+	}
+
+	re, err := regexp.Compile(`\$(\d+)`)
+
+	if err != nil {
+		return "", errorx.IllegalFormat.Wrap(err, "failed to compile positional params regex")
+	}
+
+	inlined := re.ReplaceAllStringFunc(
+		positional,
+		func(match string) string {
+			position, _ := strconv.Atoi(match[1:])
+
+			if position < 1 || position > len(names) {
+				return match
+			}
+
+			idx := slices.IndexFunc(params, func(p i.Column) bool { return p.ColumnName == names[position-1] })
+
+			if idx < 0 || params[idx].Type == "any" {
+				return "null"
+			}
+
+			paramType := params[idx].Type
+
+			if params[idx].IsArray {
+				paramType += "[]"
+			}
+
+			return fmt.Sprintf("cast(null as %s)", paramType)
+		},
+	)
+
+	return inlined, nil
+}
@@ -0,0 +1,243 @@
+package pg
+
+import (
+	_ "embed"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	i "github.com/mvoorberg/sqlxgen/internal/introspect"
+	"github.com/mvoorberg/sqlxgen/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// expectTraceNullability expects the statements tracing the nullability of
+// the result columns of a query from the view query given.
+func expectTraceNullability(mock sqlmock.Sqlmock, evAction string) {
+	mock.ExpectExec("^savepoint sqlxgen_nullability").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	mock.ExpectExec("^create temp view sample_query_introspection_view as (.+)").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	mock.ExpectQuery("select (.+) from pg_rewrite r (.+)").
+		WillReturnRows(sqlmock.NewRows([]string{"ev_action"}).AddRow(evAction))
+
+	mock.ExpectExec("^rollback to savepoint sqlxgen_nullability").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	mock.ExpectExec("^release savepoint sqlxgen_nullability").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+// untracedViewAction is a view query without any result column, nothing is
+// traced from it.
+const untracedViewAction = "({QUERY :commandType 1 :rtable <> :jointree {FROMEXPR :fromlist <> :quals <>} :targetList <>})"
+
+func TestResultOrigins(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		evAction string
+		want     map[string]columnOrigin
+		err      string
+	}{
+		{
+			name:     "nulling rels",
+			evAction: viewActionTree,
+			want: map[string]columnOrigin{
+				"id":   {TableId: "16390", ColumnId: "1"},
+				"name": {TableId: "16390", ColumnId: "2"},
+			},
+		},
+		{
+			name:     "outer join without nulling rels",
+			evAction: regexp.MustCompile(` :varnullingrels \(b( \d+)*\)`).ReplaceAllString(viewActionTree, ""),
+			want:     map[string]columnOrigin{},
+		},
+		{
+			name:     "untraced",
+			evAction: untracedViewAction,
+			want:     map[string]columnOrigin{},
+		},
+		{
+			name:     "not a query",
+			evAction: "({QUERY :targetList <>} {QUERY :targetList <>})",
+			err:      "view query is not a single query",
+		},
+		{
+			name:     "truncated",
+			evAction: "({QUERY :targetList ({TARGETENTRY :resname id",
+			err:      "unexpected end of node tree",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := resultOrigins(testCase.evAction)
+
+			if testCase.err != "" {
+				assert.ErrorContains(t, err, testCase.err)
+
+				return
+			}
+
+			assert.Nil(t, err)
+
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
+func TestParseNodeTree(t *testing.T) {
+	t.Parallel()
+
+	got, err := parseNodeTree(viewActionTree)
+
+	assert.Nil(t, err)
+
+	entries := got.List[0].field("targetList").List
+
+	assert.Len(t, entries, 5)
+
+	assert.Equal(t, "my (label)", entries[4].field("resname").Token)
+
+	assert.Equal(t, "false", entries[4].field("resjunk").Token)
+}
+
+func TestViewQuery(t *testing.T) {
+	t.Parallel()
+
+	//language=PostgreSQL
+	query := `select * from movies m
+where m.release_date > :after -- :after type: timestamp with time zone
+and m.status = any(:statuses)
+and m.title not like '%--%'
+and m.title = :title;`
+
+	params := []i.Column{
+		{ColumnName: "after", Type: "timestamp with time zone"},
+		{ColumnName: "statuses", Type: "text", IsArray: true},
+		{ColumnName: "title", Type: "any"},
+	}
+
+	got, err := viewQuery(query, params)
+
+	assert.Nil(t, err)
+
+	//language=PostgreSQL
+	want := `select * from movies m
+where m.release_date > cast(null as timestamp with time zone) 
+and m.status = any(cast(null as text[]))
+and m.title not like '%--%'
+and m.title = null`
+
+	assert.Equal(t, want, got)
+}
+
+func TestTraceNullability(t *testing.T) {
+	t.Parallel()
+
+	args := QueryArgs{Query: "select 1", Filename: "list-actors.sql"}
+
+	columns := []i.Column{
+		{ColumnName: "id", Type: "int8", Nullable: true},
+		{ColumnName: "name", Type: "text", Nullable: true},
+		{ColumnName: "title", Type: "text", Nullable: true},
+		{ColumnName: "upperName", Type: "text", Nullable: true},
+	}
+
+	t.Run("traced", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock, err := utils.NewMockSqlx()
+
+		assert.Nil(t, err)
+
+		defer func(db *sqlx.DB) {
+			_ = db.Close()
+		}(db)
+
+		mock.ExpectBegin()
+
+		expectTraceNullability(mock, viewActionTree)
+
+		mock.ExpectQuery("select (.+) from pg_attribute where attnotnull (.+)").
+			WithArgs(sqlmock.AnyArg()).
+			WillReturnRows(
+				sqlmock.NewRows([]string{"table_id", "column_id"}).
+					AddRow("16390", "1").
+					AddRow("16400", "1").
+					AddRow("16400", "2"),
+			)
+
+		tx, err := db.Beginx()
+
+		assert.Nil(t, err)
+
+		got, err := traceNullability(tx, args, nil, columns)
+
+		assert.Nil(t, err)
+
+		assert.Equal(
+			t,
+			[]i.Column{
+				{ColumnName: "id", Type: "int8", Nullable: false},
+				{ColumnName: "name", Type: "text", Nullable: true},
+				{ColumnName: "title", Type: "text", Nullable: true},
+				{ColumnName: "upperName", Type: "text", Nullable: true},
+			},
+			got,
+		)
+
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no view", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock, err := utils.NewMockSqlx()
+
+		assert.Nil(t, err)
+
+		defer func(db *sqlx.DB) {
+			_ = db.Close()
+		}(db)
+
+		mock.ExpectBegin()
+
+		mock.ExpectExec("^savepoint sqlxgen_nullability").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		mock.ExpectExec("^create temp view sample_query_introspection_view as (.+)").
+			WillReturnError(errors.New(`syntax error at or near "returning"`))
+
+		mock.ExpectExec("^rollback to savepoint sqlxgen_nullability").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		mock.ExpectExec("^release savepoint sqlxgen_nullability").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		tx, err := db.Beginx()
+
+		assert.Nil(t, err)
+
+		got, err := traceNullability(tx, args, nil, columns)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, columns, got)
+
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+}
+
+//go:embed fixtures/view-action.txt
+var viewActionTree string
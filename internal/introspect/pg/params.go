@@ -0,0 +1,139 @@
+package pg
+
+import (
+	_ "embed"
+	"fmt"
+	"log/slog"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/joomcode/errorx"
+	i "github.com/mvoorberg/sqlxgen/internal/introspect"
+)
+
+// paramType is the type postgres resolved for a $n param of the query.
+type paramType struct {
+	Position int    `db:"position"`
+	Type     string `db:"type"`
+	TypeId   string `db:"type_id"`
+	IsArray  bool   `db:"is_array"`
+}
+
+// inferParams types the params left as any by their comments after the types
+// postgres resolves when preparing the query. The query is prepared within a
+// savepoint, a query postgres can't prepare keeps its params untouched. A
+// prepared statement outlives the savepoint, so it's deallocated on every path
+// once the savepoint is rolled back or released.
+func inferParams(tx *sqlx.Tx, args QueryArgs, params []i.Column) ([]i.Column, error) {
+	if len(params) == 0 {
+		return params, nil
+	}
+
+	positional, names, err := i.PositionalParams(args.Query)
+
+	if err != nil {
+		msg := msgWithFilename(args.source(), "failed to rewrite query params")
+
+		return nil, errorx.Decorate(err, msg)
+	}
+
+	_, err = tx.Exec("savepoint sqlxgen_params")
+
+	if err != nil {
+		msg := msgWithFilename(args.source(), "failed to create params savepoint")
+
+		return nil, errorx.InternalError.Wrap(err, msg)
+	}
+
+	paramTypes, prepared, prepareErr := prepareParams(tx, positional)
+
+	if prepareErr != nil {
+		slog.Warn("unable to infer query param types", "query", args.source(), "error", prepareErr)
+
+		_, err = tx.Exec("rollback to savepoint sqlxgen_params")
+
+		if err != nil {
+			msg := msgWithFilename(args.source(), "failed to rollback params savepoint")
+
+			return nil, errorx.InternalError.Wrap(err, msg)
+		}
+	} else {
+		_, err = tx.Exec("release savepoint sqlxgen_params")
+
+		if err != nil {
+			msg := msgWithFilename(args.source(), "failed to release params savepoint")
+
+			return nil, errorx.InternalError.Wrap(err, msg)
+		}
+	}
+
+	if prepared {
+		_, err = tx.Exec("deallocate sqlxgen_params")
+
+		if err != nil {
+			msg := msgWithFilename(args.source(), "failed to deallocate query")
+
+			return nil, errorx.InternalError.Wrap(err, msg)
+		}
+	}
+
+	if prepareErr != nil {
+		return params, nil
+	}
+
+	return mergeParamTypes(params, names, paramTypes), nil
+}
+
+// prepareParams prepares the query and selects the types of its params, and
+// reports whether the statement was prepared, to deallocate it.
+func prepareParams(tx *sqlx.Tx, positional string) ([]paramType, bool, error) {
+	_, err := tx.Exec(fmt.Sprintf("prepare sqlxgen_params as %s", positional))
+
+	if err != nil {
+		return nil, false, errorx.InternalError.Wrap(err, "failed to prepare query")
+	}
+
+	paramTypes := make([]paramType, 0)
+
+	err = tx.Select(&paramTypes, paramsSql)
+
+	if err != nil {
+		return nil, true, errorx.InternalError.Wrap(err, "failed to select param types")
+	}
+
+	return paramTypes, true, nil
+}
+
+// mergeParamTypes types every param still typed as any after the first of
+// its positions postgres resolved, a type given by comment always wins.
+func mergeParamTypes(params []i.Column, names []string, paramTypes []paramType) []i.Column {
+	typesByName := make(map[string]paramType)
+
+	for _, pt := range paramTypes {
+		if pt.Position < 1 || pt.Position > len(names) || pt.Type == "unknown" {
+			continue
+		}
+
+		name := names[pt.Position-1]
+
+		if _, ok := typesByName[name]; !ok {
+			typesByName[name] = pt
+		}
+	}
+
+	merged := make([]i.Column, len(params))
+
+	for idx, param := range params {
+		if pt, ok := typesByName[param.ColumnName]; ok && param.Type == "any" {
+			param.Type = pt.Type
+			param.TypeId = pt.TypeId
+			param.IsArray = pt.IsArray
+		}
+
+		merged[idx] = param
+	}
+
+	return merged
+}
+
+//go:embed params.sql
+var paramsSql string
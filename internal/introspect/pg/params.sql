@@ -0,0 +1,11 @@
+select
+p.position as position,
+regexp_replace(tp.typname, '^_(\w+)$', '\1') as type,
+tp.oid as type_id,
+tp.typcategory = 'A' as is_array
+from pg_prepared_statements ps
+cross join unnest(ps.parameter_types) with ordinality as p(type, position)
+inner join pg_type tp on tp.oid = cast(p.type as oid)
+where true
+and ps.name = 'sqlxgen_params'
+order by p.position;
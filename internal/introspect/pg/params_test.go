@@ -0,0 +1,177 @@
+package pg
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	i "github.com/mvoorberg/sqlxgen/internal/introspect"
+	"github.com/mvoorberg/sqlxgen/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// expectInferParams expects the statements inferring the param types of a
+// query, the ones of a query without params are never run.
+func expectInferParams(mock sqlmock.Sqlmock, query string, rows *sqlmock.Rows) {
+	params, _ := i.ParseParams(query)
+
+	if len(params) == 0 {
+		return
+	}
+
+	mock.ExpectExec("^savepoint sqlxgen_params").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	mock.ExpectExec("^prepare sqlxgen_params as (.+)").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	mock.ExpectQuery("select (.+) from pg_prepared_statements ps (.+)").
+		WillReturnRows(rows)
+
+	mock.ExpectExec("^release savepoint sqlxgen_params").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	mock.ExpectExec("^deallocate sqlxgen_params").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func TestInferParams(t *testing.T) {
+	t.Parallel()
+
+	//language=PostgreSQL
+	query := `select * from movies m
+where m.release_date > :released_after -- :released_after type: timestamp with time zone
+and m.status = any(:statuses)
+and (cast(:search as text) is null or m.title ilike :search)
+and m.title <> :title;`
+
+	args := QueryArgs{Query: query, Filename: "list-movies.sql"}
+
+	params, err := i.ParseParams(query)
+
+	assert.Nil(t, err)
+
+	t.Run("inferred", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock, err := utils.NewMockSqlx()
+
+		assert.Nil(t, err)
+
+		defer func(db *sqlx.DB) {
+			_ = db.Close()
+		}(db)
+
+		mock.ExpectBegin()
+
+		expectInferParams(
+			mock,
+			query,
+			sqlmock.NewRows([]string{"position", "type", "type_id", "is_array"}).
+				AddRow(1, "timestamp", "1114", false).
+				AddRow(2, "text", "1009", true).
+				AddRow(3, "text", "25", false).
+				AddRow(4, "unknown", "705", false).
+				AddRow(5, "varchar", "1043", false),
+		)
+
+		tx, err := db.Beginx()
+
+		assert.Nil(t, err)
+
+		got, err := inferParams(tx, args, params)
+
+		assert.Nil(t, err)
+
+		assert.Equal(
+			t,
+			[]i.Column{
+				{ColumnName: "released_after", Type: "timestamp with time zone", TypeId: "0", Nullable: true, JsonType: "identity"},
+				{ColumnName: "search", Type: "text", TypeId: "25", Nullable: true, JsonType: "identity"},
+				{ColumnName: "statuses", Type: "text", TypeId: "1009", IsArray: true, Nullable: true, JsonType: "identity"},
+				{ColumnName: "title", Type: "varchar", TypeId: "1043", Nullable: true, JsonType: "identity"},
+			},
+			got,
+		)
+
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not prepared", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock, err := utils.NewMockSqlx()
+
+		assert.Nil(t, err)
+
+		defer func(db *sqlx.DB) {
+			_ = db.Close()
+		}(db)
+
+		mock.ExpectBegin()
+
+		mock.ExpectExec("^savepoint sqlxgen_params").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		mock.ExpectExec("^prepare sqlxgen_params as (.+)").
+			WillReturnError(errors.New("could not determine data type of parameter $2"))
+
+		mock.ExpectExec("^rollback to savepoint sqlxgen_params").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		tx, err := db.Beginx()
+
+		assert.Nil(t, err)
+
+		got, err := inferParams(tx, args, params)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, params, got)
+
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("param types not selected", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock, err := utils.NewMockSqlx()
+
+		assert.Nil(t, err)
+
+		defer func(db *sqlx.DB) {
+			_ = db.Close()
+		}(db)
+
+		mock.ExpectBegin()
+
+		mock.ExpectExec("^savepoint sqlxgen_params").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		mock.ExpectExec("^prepare sqlxgen_params as (.+)").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		mock.ExpectQuery("select (.+) from pg_prepared_statements ps (.+)").
+			WillReturnError(errors.New("canceling statement due to statement timeout"))
+
+		mock.ExpectExec("^rollback to savepoint sqlxgen_params").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		// the statement outlives the savepoint
+		mock.ExpectExec("^deallocate sqlxgen_params").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		tx, err := db.Beginx()
+
+		assert.Nil(t, err)
+
+		got, err := inferParams(tx, args, params)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, params, got)
+
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+}
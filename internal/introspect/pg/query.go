@@ -49,24 +49,34 @@ func (s source) IntrospectQueries(tx *sqlx.Tx) ([]i.Query, error) {
 				return nil, errorx.InitializationFailed.Wrap(err, errMsg)
 			}
 
-			qa := QueryArgs{
-				Query:    string(content),
-				Filename: fileName,
-				GenDir:   qfd.GetDir(),
-			}
+			statements, err := i.SplitQueryFile(string(content))
 
-			qas = append(qas, qa)
-		}
+			if err != nil {
+				return nil, errorx.Decorate(err, msgWithFilename(fileName, "failed to split query file"))
+			}
 
-		for _, arg := range qas {
-			q, err := introspectQuery(tx, arg)
+			for _, statement := range statements {
+				qa := QueryArgs{
+					Query:    statement.Sql,
+					Filename: fileName,
+					GenDir:   qfd.GetDir(),
+					Name:     statement.Name,
+					Command:  statement.Command,
+				}
 
-			if err != nil {
-				return nil, err
+				qas = append(qas, qa)
 			}
+		}
+	}
 
-			qs = append(qs, q)
+	for _, arg := range qas {
+		q, err := introspectQuery(tx, arg)
+
+		if err != nil {
+			return nil, err
 		}
+
+		qs = append(qs, q)
 	}
 
 	return qs, nil
@@ -75,10 +85,28 @@ func (s source) IntrospectQueries(tx *sqlx.Tx) ([]i.Query, error) {
 func introspectQuery(tx *sqlx.Tx, args QueryArgs) (i.Query, error) {
 	query := strings.TrimSpace(args.Query)
 
+	params, err := i.ParseParams(query)
+
+	if err != nil {
+		msg := msgWithFilename(args.source(), "failed to parse query params")
+
+		return i.Query{}, errorx.Decorate(err, msg)
+	}
+
+	params, err = inferParams(tx, args, params)
+
+	if err != nil {
+		return i.Query{}, err
+	}
+
+	if !i.ReturnsRows(args.Command) {
+		return newQuery(args, params, make([]i.Column, 0))
+	}
+
 	introspectionQuery, err := generateIntrospectQuery(query)
 
 	if err != nil {
-		msg := msgWithFilename(args.Filename, "failed to generate introspection query")
+		msg := msgWithFilename(args.source(), "failed to generate introspection query")
 
 		return i.Query{}, errorx.Decorate(err, msg)
 	}
@@ -86,7 +114,7 @@ func introspectQuery(tx *sqlx.Tx, args QueryArgs) (i.Query, error) {
 	jsonColumnMap, err := parseJsonColumns(query)
 
 	if err != nil {
-		msg := msgWithFilename(args.Filename, "failed to parse json columns")
+		msg := msgWithFilename(args.source(), "failed to parse json columns")
 
 		return i.Query{}, errorx.Decorate(err, msg)
 	}
@@ -94,7 +122,7 @@ func introspectQuery(tx *sqlx.Tx, args QueryArgs) (i.Query, error) {
 	nilParams, err := i.ParseParamsAsNil(query)
 
 	if err != nil {
-		msg := msgWithFilename(args.Filename, "failed to parse params as nil")
+		msg := msgWithFilename(args.source(), "failed to parse params as nil")
 
 		return i.Query{}, errorx.Decorate(err, msg)
 	}
@@ -102,7 +130,7 @@ func introspectQuery(tx *sqlx.Tx, args QueryArgs) (i.Query, error) {
 	res, err := regexp.Compile(`--\n([\w\W\s\S]*?;)\n`)
 
 	if err != nil {
-		msg := msgWithFilename(args.Filename, "failed to compile query split regex")
+		msg := msgWithFilename(args.source(), "failed to compile query split regex")
 
 		return i.Query{}, errorx.IllegalFormat.Wrap(err, msg)
 	}
@@ -110,7 +138,7 @@ func introspectQuery(tx *sqlx.Tx, args QueryArgs) (i.Query, error) {
 	statements := res.FindAllStringSubmatch(introspectionQuery, -1)
 
 	if len(statements) != 3 {
-		msg := msgWithFilename(args.Filename, "failed to generate introspection query")
+		msg := msgWithFilename(args.source(), "failed to generate introspection query")
 
 		return i.Query{}, errorx.IllegalFormat.New(msg)
 	}
@@ -120,7 +148,7 @@ func introspectQuery(tx *sqlx.Tx, args QueryArgs) (i.Query, error) {
 	_, err = tx.Exec(dropQ)
 
 	if err != nil {
-		msg := msgWithFilename(args.Filename, "failed to execute drop query")
+		msg := msgWithFilename(args.source(), "failed to execute drop query")
 
 		return i.Query{}, errorx.InternalError.Wrap(err, msg)
 	}
@@ -128,7 +156,7 @@ func introspectQuery(tx *sqlx.Tx, args QueryArgs) (i.Query, error) {
 	_, err = tx.NamedExec(createQ, nilParams)
 
 	if err != nil {
-		msg := msgWithFilename(args.Filename, "failed to execute create query")
+		msg := msgWithFilename(args.source(), "failed to execute create query")
 
 		return i.Query{}, errorx.InternalError.Wrap(err, msg)
 	}
@@ -136,7 +164,7 @@ func introspectQuery(tx *sqlx.Tx, args QueryArgs) (i.Query, error) {
 	rows, err := tx.Queryx(selectQ)
 
 	if err != nil {
-		msg := msgWithFilename(args.Filename, "failed to execute introspection query")
+		msg := msgWithFilename(args.source(), "failed to execute introspection query")
 
 		return i.Query{}, errorx.InternalError.Wrap(err, msg)
 	}
@@ -167,20 +195,68 @@ func introspectQuery(tx *sqlx.Tx, args QueryArgs) (i.Query, error) {
 		columns = append(columns, column)
 	}
 
-	params, err := i.ParseParams(query)
+	columns, err = traceNullability(tx, args, params, columns)
+
+	if err != nil {
+		return i.Query{}, err
+	}
+
+	columns, err = i.AnnotateNullability(query, columns)
 
 	if err != nil {
-		msg := msgWithFilename(args.Filename, "failed to parse query params")
+		msg := msgWithFilename(args.source(), "failed to parse nullability annotations")
 
 		return i.Query{}, errorx.Decorate(err, msg)
 	}
 
+	columns, err = i.AnnotateJsonTypes(query, columns)
+
+	if err != nil {
+		msg := msgWithFilename(args.source(), "failed to parse json type annotations")
+
+		return i.Query{}, errorx.Decorate(err, msg)
+	}
+
+	return newQuery(args, params, columns)
+}
+
+// newQuery names a query after its name header or, for a query spanning the
+// whole file, after the file.
+func newQuery(args QueryArgs, params []i.Column, columns []i.Column) (i.Query, error) {
+	if args.Name != "" {
+		pascalName, err := casing.PascalCase(args.Name)
+
+		if err != nil {
+			return i.Query{}, errorx.InternalError.Wrap(err, "failed to generate query name")
+		}
+
+		camelName, err := casing.CamelCase(args.Name)
+
+		if err != nil {
+			return i.Query{}, errorx.InternalError.Wrap(err, "failed to generate query name")
+		}
+
+		q := i.Query{
+			Filename:   args.Filename,
+			QueryName:  pascalName,
+			PascalName: pascalName,
+			CamelName:  camelName,
+			Params:     params,
+			Columns:    columns,
+			SourceDir:  args.GenDir,
+			Command:    args.Command,
+			Sql:        args.Query,
+		}
+
+		return q, nil
+	}
+
 	fileName, _ := utils.SplitFilename(args.Filename)
 
 	queryName, err := casing.PascalCase(fileName)
 
 	if err != nil {
-		msg := msgWithFilename(args.Filename, "failed to generate query name")
+		msg := msgWithFilename(args.source(), "failed to generate query name")
 
 		return i.Query{}, errorx.Decorate(err, msg)
 	}
@@ -188,7 +264,7 @@ func introspectQuery(tx *sqlx.Tx, args QueryArgs) (i.Query, error) {
 	filename, err := casing.SnakeCase(args.Filename)
 
 	if err != nil {
-		msg := msgWithFilename(args.Filename, "failed to generate query name")
+		msg := msgWithFilename(args.source(), "failed to generate query name")
 
 		return i.Query{}, errorx.Decorate(err, msg)
 	}
@@ -213,6 +289,7 @@ func introspectQuery(tx *sqlx.Tx, args QueryArgs) (i.Query, error) {
 		Params:     params,
 		Columns:    columns,
 		SourceDir:  args.GenDir,
+		Command:    args.Command,
 	}
 
 	return q, nil
@@ -256,6 +333,17 @@ type QueryArgs struct {
 	Query    string
 	Filename string
 	GenDir   string
+	Name     string
+	Command  string
+}
+
+// source identifies the query in error messages.
+func (args QueryArgs) source() string {
+	if args.Name == "" {
+		return args.Filename
+	}
+
+	return fmt.Sprintf("%s %s", args.Filename, args.Name)
 }
 
 func msgWithFilename(filename string, msg string) string {
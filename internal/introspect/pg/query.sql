@@ -11,7 +11,12 @@ tp.oid as type_id,
 tp.typcategory = 'A' as is_array,
 false as is_sequence,
 not attr.attnotnull as nullable,
-attr.attgenerated = 's' as generated
+attr.attgenerated = 's' as generated,
+(
+  select json_agg(e.enumlabel order by e.enumsortorder)
+  from pg_enum e
+  where e.enumtypid = tp.oid
+) as enum_values
 from pg_attribute attr
 inner join pg_type tp on tp.oid = attr.atttypid
 where true
@@ -79,7 +79,12 @@ tp.oid as type_id,
 tp.typcategory = 'A' as is_array,
 false as is_sequence,
 not attr.attnotnull as nullable,
-attr.attgenerated = 's' as generated
+attr.attgenerated = 's' as generated,
+(
+  select json_agg(e.enumlabel order by e.enumsortorder)
+  from pg_enum e
+  where e.enumtypid = tp.oid
+) as enum_values
 from pg_attribute attr
 inner join pg_type tp on tp.oid = attr.atttypid
 where true
@@ -111,7 +116,12 @@ tp.oid as type_id,
 tp.typcategory = 'A' as is_array,
 false as is_sequence,
 not attr.attnotnull as nullable,
-attr.attgenerated = 's' as generated
+attr.attgenerated = 's' as generated,
+(
+  select json_agg(e.enumlabel order by e.enumsortorder)
+  from pg_enum e
+  where e.enumtypid = tp.oid
+) as enum_values
 from pg_attribute attr
 inner join pg_type tp on tp.oid = attr.atttypid
 where true
@@ -201,7 +211,12 @@ tp.oid as type_id,
 tp.typcategory = 'A' as is_array,
 false as is_sequence,
 not attr.attnotnull as nullable,
-attr.attgenerated = 's' as generated
+attr.attgenerated = 's' as generated,
+(
+  select json_agg(e.enumlabel order by e.enumsortorder)
+  from pg_enum e
+  where e.enumtypid = tp.oid
+) as enum_values
 from pg_attribute attr
 inner join pg_type tp on tp.oid = attr.atttypid
 where true
@@ -528,7 +543,12 @@ tp.oid as type_id,
 tp.typcategory = 'A' as is_array,
 false as is_sequence,
 not attr.attnotnull as nullable,
-attr.attgenerated = 's' as generated
+attr.attgenerated = 's' as generated,
+(
+  select json_agg(e.enumlabel order by e.enumsortorder)
+  from pg_enum e
+  where e.enumtypid = tp.oid
+) as enum_values
 from pg_attribute attr
 inner join pg_type tp on tp.oid = attr.atttypid
 where true
@@ -626,7 +646,12 @@ tp.oid as type_id,
 tp.typcategory = 'A' as is_array,
 false as is_sequence,
 not attr.attnotnull as nullable,
-attr.attgenerated = 's' as generated
+attr.attgenerated = 's' as generated,
+(
+  select json_agg(e.enumlabel order by e.enumsortorder)
+  from pg_enum e
+  where e.enumtypid = tp.oid
+) as enum_values
 from pg_attribute attr
 inner join pg_type tp on tp.oid = attr.atttypid
 where true
@@ -711,6 +736,8 @@ func TestIntrospectQuery(t *testing.T) {
 	mock.ExpectBegin()
 
 	for _, testCase := range testCases {
+		expectInferParams(mock, testCase.args.Query, sqlmock.NewRows([]string{"position", "type", "type_id", "is_array"}))
+
 		mock.ExpectExec("drop table if exists sample_query_introspection").
 			WillReturnResult(
 				sqlmock.NewResult(0, 0),
@@ -726,6 +753,8 @@ func TestIntrospectQuery(t *testing.T) {
 				sqlmock.NewRows([]string{"column_name", "type", "type_id", "is_array", "is_sequence", "nullable", "generated"}).
 					FromCSVString(testCase.resultsCsv),
 			)
+
+		expectTraceNullability(mock, untracedViewAction)
 	}
 
 	mock.ExpectRollback()
@@ -833,6 +862,8 @@ func TestIntrospectQueries(t *testing.T) {
 	mock.ExpectBegin()
 
 	for _, testCase := range testCases {
+		expectInferParams(mock, testCase.query, sqlmock.NewRows([]string{"position", "type", "type_id", "is_array"}))
+
 		mock.ExpectExec("drop table if exists sample_query_introspection").
 			WillReturnResult(
 				sqlmock.NewResult(0, 0),
@@ -848,6 +879,8 @@ func TestIntrospectQueries(t *testing.T) {
 				sqlmock.NewRows([]string{"column_name", "type", "type_id", "is_array", "is_sequence", "nullable", "generated"}).
 					FromCSVString(testCase.result),
 			)
+
+		expectTraceNullability(mock, untracedViewAction)
 	}
 
 	mock.ExpectRollback()
@@ -889,6 +922,87 @@ func TestIntrospectQueries(t *testing.T) {
 	}
 }
 
+func TestIntrospectQueries_Dirs(t *testing.T) {
+	t.Parallel()
+
+	// the fake discovery finds the same file in every query dir
+	fd := fs.NewFakeFileDiscovery(
+		[]fs.FakeDiscover{
+			{
+				Content:  getActorQuery,
+				Dir:      "fixtures",
+				Filename: "get-actor.sql",
+				FullPath: "fixtures/get-actor.sql",
+			},
+		},
+	)
+
+	s := NewIntrospect(fd, IntrospectArgs{QueryDirs: []string{"actors", "movies"}})
+
+	db, mock, err := utils.NewMockSqlx()
+
+	if err != nil {
+		t.Fatalf("failed to create mock db: %v", err)
+	}
+
+	defer func(db *sqlx.DB) {
+		err := db.Close()
+
+		if err != nil {
+			t.Fatalf("failed to close mock db: %v", err)
+		}
+	}(db)
+
+	mock.ExpectBegin()
+
+	// once per query dir
+	for range []string{"actors", "movies"} {
+		expectInferParams(mock, getActorQuery, sqlmock.NewRows([]string{"position", "type", "type_id", "is_array"}))
+
+		mock.ExpectExec("drop table if exists sample_query_introspection").
+			WillReturnResult(
+				sqlmock.NewResult(0, 0),
+			)
+
+		mock.ExpectExec("create temp table if not exists sample_query_introspection (.+)").
+			WillReturnResult(
+				sqlmock.NewResult(0, 0),
+			)
+
+		mock.ExpectQuery("select (.+) from pg_attribute attr (.+)").
+			WillReturnRows(
+				sqlmock.NewRows([]string{"column_name", "type", "type_id", "is_array", "is_sequence", "nullable", "generated"}).
+					FromCSVString(getActorResultCsv),
+			)
+
+		expectTraceNullability(mock, untracedViewAction)
+	}
+
+	mock.ExpectRollback()
+
+	mock.ExpectClose()
+
+	tx, err := db.Beginx()
+
+	if err != nil {
+		t.Fatalf("failed to create transaction: %v", err)
+	}
+
+	defer func(tx *sqlx.Tx) {
+		err := tx.Rollback()
+
+		if err != nil {
+			t.Fatalf("failed to rollback transaction: %v", err)
+		}
+	}(tx)
+
+	queries, err := s.IntrospectQueries(tx)
+
+	assert.NoError(t, err)
+
+	assert.Len(t, queries, 2)
+}
+
 //go:embed fixtures/list-actors.sql
 var listActorsQuery string
 
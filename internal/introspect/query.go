@@ -8,4 +8,10 @@ type Query struct {
 	Columns    Columns `db:"columns" json:"columns"`
 	Params     Columns `db:"params" json:"params"`
 	SourceDir  string  `db:"source_dir" json:"source_dir"`
+	// Command is one, many, exec or execrows, from the name header or the
+	// returns annotation of the query
+	Command string `db:"command" json:"command,omitempty"`
+	// Sql is the statement of a named query, queries without a name header
+	// span the whole file which is embedded instead
+	Sql string `db:"sql" json:"sql,omitempty"`
 }
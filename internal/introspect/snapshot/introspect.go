@@ -0,0 +1,133 @@
+package snapshot
+
+import (
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/joomcode/errorx"
+	i "github.com/mvoorberg/sqlxgen/internal/introspect"
+	"github.com/mvoorberg/sqlxgen/internal/utils/fs"
+)
+
+type source struct {
+	fd     fs.FileDiscovery
+	args   IntrospectArgs
+	config Config
+}
+
+// NewIntrospect reads the tables and queries from the snapshot of a config,
+// query files that are new or changed since need a database.
+func NewIntrospect(fd fs.FileDiscovery, args IntrospectArgs, config Config) i.Introspect {
+	return source{
+		fd:     fd,
+		args:   args,
+		config: config,
+	}
+}
+
+func (s source) IntrospectSchema(_ *sqlx.Tx) ([]i.Table, error) {
+	return s.config.Tables, nil
+}
+
+func (s source) IntrospectQueries(_ *sqlx.Tx) ([]i.Query, error) {
+	files, err := discoverQueryFiles(s.fd, s.args)
+
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make(map[string]QueryFile)
+
+	for _, qf := range s.config.QueryFiles {
+		snapshots[qf.Path] = qf
+	}
+
+	queries := make([]i.Query, 0)
+
+	stale := make([]string, 0)
+
+	for _, file := range files {
+		qf, ok := snapshots[file.path]
+
+		if !ok || qf.Hash != file.hash {
+			stale = append(stale, file.path)
+
+			continue
+		}
+
+		queries = append(queries, qf.Queries...)
+	}
+
+	if len(stale) > 0 {
+		return nil, errorx.IllegalState.New(
+			"query files are new or changed since the snapshot, run sqlxgen generate --save-snapshot with a database: %s",
+			strings.Join(stale, ", "),
+		)
+	}
+
+	return queries, nil
+}
+
+type recorder struct {
+	fd         fs.FileDiscovery
+	args       IntrospectArgs
+	introspect i.Introspect
+	config     *Config
+}
+
+// NewRecorder introspects with introspect and records the tables and
+// queries into config.
+func NewRecorder(fd fs.FileDiscovery, args IntrospectArgs, introspect i.Introspect, config *Config) i.Introspect {
+	return recorder{
+		fd:         fd,
+		args:       args,
+		introspect: introspect,
+		config:     config,
+	}
+}
+
+func (r recorder) IntrospectSchema(tx *sqlx.Tx) ([]i.Table, error) {
+	tables, err := r.introspect.IntrospectSchema(tx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	r.config.Tables = tables
+
+	return tables, nil
+}
+
+func (r recorder) IntrospectQueries(tx *sqlx.Tx) ([]i.Query, error) {
+	queries, err := r.introspect.IntrospectQueries(tx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := discoverQueryFiles(r.fd, r.args)
+
+	if err != nil {
+		return nil, err
+	}
+
+	r.config.QueryFiles = make([]QueryFile, len(files))
+
+	for idx, file := range files {
+		qf := QueryFile{
+			Path:    file.path,
+			Hash:    file.hash,
+			Queries: make([]i.Query, 0),
+		}
+
+		for _, query := range queries {
+			if queryPath(query) == file.path {
+				qf.Queries = append(qf.Queries, query)
+			}
+		}
+
+		r.config.QueryFiles[idx] = qf
+	}
+
+	return queries, nil
+}
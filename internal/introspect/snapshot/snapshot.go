@@ -0,0 +1,164 @@
+package snapshot
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path"
+
+	"github.com/joomcode/errorx"
+	i "github.com/mvoorberg/sqlxgen/internal/introspect"
+	"github.com/mvoorberg/sqlxgen/internal/utils"
+	"github.com/mvoorberg/sqlxgen/internal/utils/fs"
+)
+
+// Version of the lock file, it changes whenever a field is removed or
+// changes meaning.
+const Version = "1"
+
+// FileName of the lock file, in the project directory.
+const FileName = "sqlxgen.lock.json"
+
+// Lock holds the introspection of every config, to generate without a
+// database.
+type Lock struct {
+	Version string   `json:"version"`
+	Configs []Config `json:"configs"`
+}
+
+type Config struct {
+	Name       string      `json:"name"`
+	Tables     []i.Table   `json:"tables"`
+	QueryFiles []QueryFile `json:"query_files"`
+}
+
+// QueryFile holds the queries introspected from the file at Path, as long
+// as its content hashes to Hash.
+type QueryFile struct {
+	Path    string    `json:"path"`
+	Hash    string    `json:"hash"`
+	Queries []i.Query `json:"queries"`
+}
+
+type IntrospectArgs struct {
+	QueryDirs       []string
+	QueryInclusions []string
+	QueryExclusions []string
+}
+
+func NewLock() Lock {
+	return Lock{
+		Version: Version,
+		Configs: make([]Config, 0),
+	}
+}
+
+func Load(lockPath string) (Lock, error) {
+	content, err := os.ReadFile(lockPath)
+
+	if err != nil {
+		return Lock{}, errorx.InitializationFailed.Wrap(
+			err,
+			"unable to read %s, run sqlxgen generate --save-snapshot with a database first",
+			lockPath,
+		)
+	}
+
+	var lock Lock
+
+	err = json.Unmarshal(content, &lock)
+
+	if err != nil {
+		return Lock{}, errorx.IllegalFormat.Wrap(err, "unable to parse %s", lockPath)
+	}
+
+	if lock.Version != Version {
+		return Lock{}, errorx.IllegalFormat.New("unsupported version %s of %s", lock.Version, lockPath)
+	}
+
+	return lock, nil
+}
+
+func (l Lock) Save(lockPath string) error {
+	content, err := json.MarshalIndent(l, "", "  ")
+
+	if err != nil {
+		return errorx.InternalError.Wrap(err, "unable to marshal snapshot")
+	}
+
+	err = os.WriteFile(lockPath, append(content, '\n'), 0644)
+
+	if err != nil {
+		return errorx.InternalError.Wrap(err, "unable to write %s", lockPath)
+	}
+
+	return nil
+}
+
+// Config returns the snapshot of the config with the given name.
+func (l Lock) Config(name string) (Config, error) {
+	for _, cfg := range l.Configs {
+		if cfg.Name == name {
+			return cfg, nil
+		}
+	}
+
+	return Config{}, errorx.IllegalArgument.New(
+		"no snapshot of config %s, run sqlxgen generate --save-snapshot with a database",
+		name,
+	)
+}
+
+type queryFile struct {
+	path string
+	hash string
+}
+
+// discoverQueryFiles finds the query files like the introspection of the
+// engines does.
+func discoverQueryFiles(fd fs.FileDiscovery, args IntrospectArgs) ([]queryFile, error) {
+	validateQueryName, err := utils.CreateValidateEntityNames(args.QueryInclusions, args.QueryExclusions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]queryFile, 0)
+
+	for _, queryDir := range args.QueryDirs {
+		qfds, err := fd.Find(queryDir, `[\w-]+.sql$`, false)
+
+		if err != nil {
+			return nil, errorx.InitializationFailed.Wrap(err, "failed to discover query files")
+		}
+
+		for _, qfd := range qfds {
+			if !validateQueryName(qfd.GetFilename()) {
+				continue
+			}
+
+			content, err := qfd.Load()
+
+			if err != nil {
+				return nil, errorx.InitializationFailed.Wrap(err, "failed to load query file %s", qfd.GetFullPath())
+			}
+
+			sum := sha256.Sum256(content)
+
+			files = append(
+				files,
+				queryFile{
+					path: path.Join(qfd.GetDir(), qfd.GetFilename()),
+					hash: hex.EncodeToString(sum[:]),
+				},
+			)
+		}
+	}
+
+	return files, nil
+}
+
+func queryPath(query i.Query) string {
+	return path.Join(query.SourceDir, query.Filename)
+}
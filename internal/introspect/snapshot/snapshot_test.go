@@ -0,0 +1,148 @@
+package snapshot
+
+import (
+	"path"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	i "github.com/mvoorberg/sqlxgen/internal/introspect"
+	"github.com/mvoorberg/sqlxgen/internal/utils/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeIntrospect struct {
+	tables  []i.Table
+	queries []i.Query
+}
+
+func (f fakeIntrospect) IntrospectSchema(_ *sqlx.Tx) ([]i.Table, error) {
+	return f.tables, nil
+}
+
+func (f fakeIntrospect) IntrospectQueries(_ *sqlx.Tx) ([]i.Query, error) {
+	return f.queries, nil
+}
+
+func withQueryFiles(contents ...string) fs.FileDiscovery {
+	fds := make([]fs.FakeDiscover, 0)
+
+	for idx, content := range contents {
+		filename := []string{"get-actor.sql", "list-actors.sql", "list-movies.sql"}[idx]
+
+		fds = append(
+			fds,
+			fs.FakeDiscover{
+				Content:  content,
+				Dir:      "queries",
+				Filename: filename,
+				FullPath: path.Join("queries", filename),
+			},
+		)
+	}
+
+	return fs.NewFakeFileDiscovery(fds)
+}
+
+func TestSnapshot(t *testing.T) {
+	t.Parallel()
+
+	args := IntrospectArgs{QueryDirs: []string{"queries"}, QueryExclusions: []string{"^list-movies"}}
+
+	live := fakeIntrospect{
+		tables: []i.Table{{SchemaName: "public", TableName: "actors"}},
+		queries: []i.Query{
+			{Filename: "get-actor.sql", SourceDir: "queries", QueryName: "get-actor"},
+			{Filename: "list-actors.sql", SourceDir: "queries", QueryName: "ListActors", Sql: "select 1"},
+			{Filename: "list-actors.sql", SourceDir: "queries", QueryName: "CountActors", Sql: "select 2"},
+		},
+	}
+
+	recorded := &Config{Name: "pg"}
+
+	recorder := NewRecorder(withQueryFiles("select 1", "select 2", "select 3"), args, live, recorded)
+
+	tables, err := recorder.IntrospectSchema(nil)
+
+	assert.Nil(t, err)
+
+	assert.Equal(t, live.tables, tables)
+
+	queries, err := recorder.IntrospectQueries(nil)
+
+	assert.Nil(t, err)
+
+	assert.Equal(t, live.queries, queries)
+
+	assert.Equal(t, live.tables, recorded.Tables)
+
+	assert.Equal(
+		t,
+		[]QueryFile{
+			{
+				Path:    "queries/get-actor.sql",
+				Hash:    "822ae07d4783158bc1912bb623e5107cc9002d519e1143a9c200ed6ee18b6d0f",
+				Queries: live.queries[:1],
+			},
+			{
+				Path:    "queries/list-actors.sql",
+				Hash:    "3cf988cc782b44bc24ceb17e445d9c3cfd06b6c848ecfff949e1bfdb9f705a61",
+				Queries: live.queries[1:],
+			},
+		},
+		recorded.QueryFiles,
+	)
+
+	lockPath := path.Join(t.TempDir(), FileName)
+
+	lock := NewLock()
+
+	lock.Configs = append(lock.Configs, *recorded)
+
+	err = lock.Save(lockPath)
+
+	assert.Nil(t, err)
+
+	loaded, err := Load(lockPath)
+
+	assert.Nil(t, err)
+
+	assert.Equal(t, lock, loaded)
+
+	_, err = loaded.Config("mysql")
+
+	assert.ErrorContains(t, err, "no snapshot of config mysql")
+
+	snapshot, err := loaded.Config("pg")
+
+	assert.Nil(t, err)
+
+	t.Run("unchanged", func(t *testing.T) {
+		t.Parallel()
+
+		offline := NewIntrospect(withQueryFiles("select 1", "select 2", "changed"), args, snapshot)
+
+		tables, err := offline.IntrospectSchema(nil)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, live.tables, tables)
+
+		queries, err := offline.IntrospectQueries(nil)
+
+		assert.Nil(t, err)
+
+		assert.Equal(t, live.queries, queries)
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		t.Parallel()
+
+		offline := NewIntrospect(withQueryFiles("select 1", "select 2;"), args, snapshot)
+
+		_, err := offline.IntrospectQueries(nil)
+
+		assert.ErrorContains(t, err, "query files are new or changed since the snapshot")
+
+		assert.ErrorContains(t, err, "queries/list-actors.sql")
+	})
+}
@@ -0,0 +1,21 @@
+-- name: GetActor :one
+select
+a.id as "id",
+a.name as "name"
+from actors a
+where a.id = :id; -- :id type: int
+
+-- name: ListActorMovies :many
+select
+m.id as "id",
+m.title as "title",
+ma.character as "character"
+from movies_actors ma
+inner join movies m on ma.movie_id = m.id
+where ma.actor_id = :actor_id -- :actor_id type: int
+order by ma.cast_order;
+
+-- name: RenameActor :execrows
+update actors
+set name = :name -- :name type: text
+where id = :id; -- :id type: int
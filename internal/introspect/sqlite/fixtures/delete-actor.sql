@@ -0,0 +1,3 @@
+-- returns: none
+delete from actors
+where id = :id; -- :id type: int
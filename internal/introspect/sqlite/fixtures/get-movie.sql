@@ -0,0 +1,24 @@
+select
+m.id as "id",
+m.title as "title",
+m.release_date as "releaseDate",
+m.vote_average as "voteAverage",
+cast(m.runtime as integer) as "runtime",
+coalesce(
+  (
+    select
+    json_group_array(
+      json_object(
+        'id', a.id,
+        'name', a.name,
+        'character', ma.character
+      )
+    )
+    from movies_actors ma
+    inner join actors a on a.id = ma.actor_id
+    where ma.movie_id = m.id
+  ),
+  '[]'
+) as "actors"
+from movies m
+where m.id = :id; -- :id type: integer
@@ -0,0 +1,14 @@
+select
+count(*) over () as "totalRecordsCount",
+a.id as "id",
+a.name as "name"
+from actors a
+where true
+and (
+  false
+  or :search is null
+  or a.name_search like :search
+) -- :search type: text
+order by a.id
+limit :limit -- :limit type: int
+offset :offset; -- :offset type: int
@@ -0,0 +1,32 @@
+create table actors (
+  id integer primary key,
+  name varchar(255) not null unique,
+  name_search text generated always as (lower(name)) virtual
+);
+
+create table movies (
+  id integer primary key autoincrement,
+  title text not null,
+  release_date date,
+  runtime int,
+  budget numeric(12, 2),
+  vote_average real,
+  adult boolean not null default false,
+  poster blob,
+  keywords json,
+  created_at datetime not null default current_timestamp
+);
+
+create table movies_actors (
+  movie_id integer not null references movies (id) on delete cascade,
+  actor_id integer not null references actors on delete cascade,
+  character text,
+  cast_order int,
+  primary key (movie_id, actor_id)
+);
+
+create unique index movies_title_release_date_key on movies (title, release_date);
+
+create view movie_titles as
+select m.id, m.title
+from movies m;
@@ -0,0 +1,139 @@
+package sqlite
+
+import (
+	"cmp"
+	_ "embed"
+	"fmt"
+	"slices"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/joomcode/errorx"
+	i "github.com/mvoorberg/sqlxgen/internal/introspect"
+	"github.com/mvoorberg/sqlxgen/internal/utils"
+)
+
+func (s source) IntrospectSchema(tx *sqlx.Tx) ([]i.Table, error) {
+	tables := make([]i.Table, 0)
+
+	validateTableName, err := utils.CreateValidateEntityNames(s.args.TableInclusions, s.args.TableExclusions)
+
+	if err != nil {
+		return tables, err
+	}
+
+	for _, schema := range s.args.Schemas {
+		rows, err := tx.NamedQuery(
+			introspectSchemaSql,
+			map[string]interface{}{
+				"schema": schema,
+			},
+		)
+
+		if err != nil {
+			msg := msgWithSchema(schema, "failed to introspect schema")
+
+			return tables, errorx.Decorate(err, msg)
+		}
+
+		schemaTables := make([]i.Table, 0)
+
+		for rows.Next() {
+			table := i.Table{}
+
+			err = rows.StructScan(&table)
+
+			if err != nil {
+				msg := msgWithSchema(schema, "failed to scan table")
+
+				return tables, errorx.Decorate(err, msg)
+			}
+
+			slices.SortStableFunc(
+				table.Columns,
+				func(a, b i.Column) int {
+					// primary key columns first, in key order, then the others by name
+					if (a.PkOrdinalPosition == 0) != (b.PkOrdinalPosition == 0) {
+						return cmp.Compare(b.PkOrdinalPosition, a.PkOrdinalPosition)
+					}
+
+					ordinal := cmp.Compare(a.PkOrdinalPosition, b.PkOrdinalPosition)
+
+					if ordinal != 0 {
+						return ordinal
+					}
+
+					return cmp.Compare(a.ColumnName, b.ColumnName)
+				},
+			)
+
+			slices.SortStableFunc(
+				table.UniqueKeys,
+				func(a, b i.UniqueKey) int {
+					return cmp.Compare(a.ConstraintName, b.ConstraintName)
+				},
+			)
+
+			schemaTables = append(schemaTables, table)
+		}
+
+		err = rows.Close()
+
+		if err != nil {
+			msg := msgWithSchema(schema, "failed to close introspection result")
+
+			return tables, errorx.Decorate(err, msg)
+		}
+
+		resolveImplicitRefColumns(schemaTables)
+
+		for _, table := range schemaTables {
+			if !validateTableName(table.TableName) {
+				continue
+			}
+
+			tables = append(tables, table)
+		}
+	}
+
+	return tables, nil
+}
+
+// resolveImplicitRefColumns fills the referenced columns of foreign keys
+// declared without them (e.g. "references actors"), which sqlite reports as
+// null and which target the primary key of the parent table.
+func resolveImplicitRefColumns(tables []i.Table) {
+	byName := make(map[string]i.Table)
+
+	for _, table := range tables {
+		byName[table.TableName] = table
+	}
+
+	for _, table := range tables {
+		for idx, fk := range table.ForeignKeys {
+			if !slices.Contains(fk.RefColumns, "") {
+				continue
+			}
+
+			parent, ok := byName[fk.RefTableName]
+
+			if !ok {
+				continue
+			}
+
+			refColumns := make([]string, 0)
+
+			for _, column := range parent.PrimaryKey() {
+				refColumns = append(refColumns, column.ColumnName)
+			}
+
+			table.ForeignKeys[idx].RefColumns = refColumns
+		}
+	}
+}
+
+//go:embed model.sql
+var introspectSchemaSql string
+
+func msgWithSchema(schema string, msg string) string {
+	return fmt.Sprintf("%s: %s", schema, msg)
+}
@@ -0,0 +1,86 @@
+select
+tl.schema as schema_name,
+tl.name as table_name,
+cast((
+  select
+  json_group_array(
+    json_object(
+      'column_name', ti.name,
+      'type', lower(trim(case when instr(ti.type, '(') > 0 then substr(ti.type, 1, instr(ti.type, '(') - 1) else ti.type end)),
+      'type_id', '0',
+      'is_array', json('false'),
+      'is_sequence', json(
+        case
+          when ti.pk = 1
+          and lower(ti.type) = 'integer'
+          and (select count(*) from pragma_table_info(tl.name, tl.schema) tpk where tpk.pk > 0) = 1
+          then 'true'
+          else 'false'
+        end
+      ),
+      'nullable', json(case when ti."notnull" = 0 and ti.pk = 0 then 'true' else 'false' end),
+      'generated', json(case when ti.hidden in (2, 3) then 'true' else 'false' end),
+      'pk_name', case when ti.pk > 0 then tl.name || '_pkey' else '' end,
+      'pk_ordinal_position', ti.pk
+    )
+  )
+  from pragma_table_xinfo(tl.name, tl.schema) ti
+  where ti.hidden != 1
+) as blob) as columns,
+cast((
+  select
+  json_group_array(json(fk.foreign_key))
+  from (
+    select
+    json_object(
+      'constraint_name', tl.name || '_' || group_concat(fkl."from", '_') || '_fkey',
+      'columns', json_group_array(fkl."from"),
+      'ref_schema_name', tl.schema,
+      'ref_table_name', fkl."table",
+      'ref_columns', json_group_array(fkl."to"),
+      'on_delete', fkl.on_delete,
+      'on_update', fkl.on_update
+    ) as foreign_key
+    from (
+      select *
+      from pragma_foreign_key_list(tl.name, tl.schema)
+      order by id, seq
+    ) fkl
+    group by fkl.id
+  ) fk
+) as blob) as foreign_keys,
+cast((
+  select
+  json_group_array(
+    json_object(
+      'constraint_name', case
+        when il.origin = 'u' then tl.name || '_' || (
+          select group_concat(ii.name, '_')
+          from (select name from pragma_index_info(il.name, tl.schema) order by seqno) ii
+        ) || '_key'
+        else il.name
+      end,
+      'columns', (
+        select json_group_array(ii.name)
+        from (select name from pragma_index_info(il.name, tl.schema) order by seqno) ii
+      )
+    )
+  )
+  from (
+    select *
+    from pragma_index_list(tl.name, tl.schema)
+    order by name
+  ) il
+  where true
+  and il."unique" = 1
+  and il.origin != 'pk'
+  and il.partial = 0
+  -- expression indexes have no column name and can't be targeted
+  and not exists (select 1 from pragma_index_info(il.name, tl.schema) ii where ii.name is null)
+) as blob) as unique_keys
+from pragma_table_list tl
+where true
+and tl.schema = :schema
+and tl.type in ('table', 'view')
+and tl.name not like 'sqlite_%'
+order by tl.name;
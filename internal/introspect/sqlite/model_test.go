@@ -0,0 +1,84 @@
+package sqlite
+
+import (
+	_ "embed"
+	"encoding/json"
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy"
+	"github.com/jmoiron/sqlx"
+	"github.com/mvoorberg/sqlxgen/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMsgWithSchema(t *testing.T) {
+	t.Parallel()
+
+	got := msgWithSchema("main", "failed to introspect schema")
+
+	assert.Equal(t, "main: failed to introspect schema", got)
+}
+
+func TestIntrospectSchema(t *testing.T) {
+	t.Parallel()
+
+	db, err := utils.NewTempSqlite(t.TempDir(), schemaSql)
+
+	if err != nil {
+		t.Fatalf("failed to create sqlite db: %v", err)
+	}
+
+	defer func(db *sqlx.DB) {
+		err := db.Close()
+
+		if err != nil {
+			t.Fatalf("failed to close sqlite db: %v", err)
+		}
+	}(db)
+
+	args := IntrospectArgs{
+		Schemas:         []string{"main"},
+		TableInclusions: []string{},
+		TableExclusions: []string{},
+		QueryDirs:       []string{},
+		QueryInclusions: []string{},
+		QueryExclusions: []string{},
+	}
+
+	source := NewIntrospect(nil, args)
+
+	tx, err := db.Beginx()
+
+	if err != nil {
+		t.Fatalf("failed to create transaction: %v", err)
+	}
+
+	defer func(tx *sqlx.Tx) {
+		err := tx.Rollback()
+
+		if err != nil {
+			t.Fatalf("failed to rollback transaction: %v", err)
+		}
+	}(tx)
+
+	tables, err := source.IntrospectSchema(tx)
+
+	assert.Nil(t, err)
+
+	assert.Equal(t, 4, len(tables))
+
+	for _, table := range tables {
+		t.Run(table.TableName, func(t *testing.T) {
+			tableJson, err := json.MarshalIndent(table, "", "  ")
+
+			if err != nil {
+				t.Fatalf("failed to marshal table: %v", err)
+			}
+
+			cupaloy.SnapshotT(t, tableJson)
+		})
+	}
+}
+
+//go:embed fixtures/schema.sql
+var schemaSql string
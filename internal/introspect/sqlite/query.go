@@ -0,0 +1,416 @@
+package sqlite
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/joomcode/errorx"
+	i "github.com/mvoorberg/sqlxgen/internal/introspect"
+	"github.com/mvoorberg/sqlxgen/internal/introspect/prepare"
+	"github.com/mvoorberg/sqlxgen/internal/utils"
+	"github.com/mvoorberg/sqlxgen/internal/utils/casing"
+)
+
+func (s source) IntrospectQueries(tx *sqlx.Tx) ([]i.Query, error) {
+	validateQueryName, err := utils.CreateValidateEntityNames(s.args.QueryInclusions, s.args.QueryExclusions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	qas := make([]QueryArgs, 0)
+
+	qs := make([]i.Query, 0)
+
+	for _, queryDir := range s.args.QueryDirs {
+		qfds, err := s.fd.Find(queryDir, `[\w-]+.sql$`, false)
+
+		if err != nil {
+			return nil, errorx.InitializationFailed.Wrap(err, "failed to discover query files")
+		}
+
+		for _, qfd := range qfds {
+			content, err := qfd.Load()
+
+			if err != nil {
+				errMsg := fmt.Sprintf("failed to load query file %s", qfd.GetFullPath())
+
+				return nil, errorx.InitializationFailed.Wrap(err, errMsg)
+			}
+
+			fileName := qfd.GetFilename()
+
+			if !validateQueryName(fileName) {
+				continue
+			}
+
+			statements, err := i.SplitQueryFile(string(content))
+
+			if err != nil {
+				return nil, errorx.Decorate(err, msgWithFilename(fileName, "failed to split query file"))
+			}
+
+			for _, statement := range statements {
+				qa := QueryArgs{
+					Query:    statement.Sql,
+					Filename: fileName,
+					GenDir:   qfd.GetDir(),
+					Name:     statement.Name,
+					Command:  statement.Command,
+				}
+
+				qas = append(qas, qa)
+			}
+		}
+	}
+
+	for _, arg := range qas {
+		q, err := introspectQuery(tx, arg)
+
+		if err != nil {
+			return nil, err
+		}
+
+		qs = append(qs, q)
+	}
+
+	return qs, nil
+}
+
+func introspectQuery(tx *sqlx.Tx, args QueryArgs) (i.Query, error) {
+	query := strings.TrimSpace(args.Query)
+
+	introspectionQuery, err := generateIntrospectQuery(query)
+
+	if err != nil {
+		msg := msgWithFilename(args.source(), "failed to generate introspection query")
+
+		return i.Query{}, errorx.Decorate(err, msg)
+	}
+
+	jsonColumnMap, err := parseJsonColumns(query)
+
+	if err != nil {
+		msg := msgWithFilename(args.source(), "failed to parse json columns")
+
+		return i.Query{}, errorx.Decorate(err, msg)
+	}
+
+	params, err := i.ParseParams(query)
+
+	if err != nil {
+		msg := msgWithFilename(args.source(), "failed to parse query params")
+
+		return i.Query{}, errorx.Decorate(err, msg)
+	}
+
+	if !i.ReturnsRows(args.Command) {
+		return newQuery(args, params, make([]i.Column, 0))
+	}
+
+	sampleParams := sampleQueryParams(params)
+
+	res, err := regexp.Compile(`--\n([\w\W\s\S]*?;)\n`)
+
+	if err != nil {
+		msg := msgWithFilename(args.source(), "failed to compile query split regex")
+
+		return i.Query{}, errorx.IllegalFormat.Wrap(err, msg)
+	}
+
+	statements := res.FindAllStringSubmatch(introspectionQuery, -1)
+
+	if len(statements) != 3 {
+		msg := msgWithFilename(args.source(), "failed to generate introspection query")
+
+		return i.Query{}, errorx.IllegalFormat.New(msg)
+	}
+
+	dropQ, createQ, selectQ := statements[0][1], statements[1][1], statements[2][1]
+
+	_, err = tx.Exec(dropQ)
+
+	if err != nil {
+		msg := msgWithFilename(args.source(), "failed to execute drop query")
+
+		return i.Query{}, errorx.InternalError.Wrap(err, msg)
+	}
+
+	_, err = tx.NamedExec(createQ, sampleParams)
+
+	if err != nil {
+		msg := msgWithFilename(args.source(), "failed to execute create query")
+
+		return i.Query{}, errorx.InternalError.Wrap(err, msg)
+	}
+
+	declaredTypes, err := introspectDeclaredTypes(tx, query, sampleParams)
+
+	if err != nil {
+		msg := msgWithFilename(args.source(), "failed to introspect declared types")
+
+		return i.Query{}, errorx.Decorate(err, msg)
+	}
+
+	rows, err := tx.Queryx(selectQ)
+
+	if err != nil {
+		msg := msgWithFilename(args.source(), "failed to execute introspection query")
+
+		return i.Query{}, errorx.InternalError.Wrap(err, msg)
+	}
+
+	columns := make([]i.Column, 0)
+
+	for rows.Next() {
+		column := i.Column{}
+
+		err := rows.StructScan(&column)
+
+		if err != nil {
+			return i.Query{}, errorx.InternalError.Wrap(err, "failed to scan introspection query result")
+		}
+
+		// the temp table only carries the affinity of each column, prefer the
+		// type declared on the source table when there is one
+		if idx := len(columns); idx < len(declaredTypes) && declaredTypes[idx] != "" {
+			column.Type = declaredTypes[idx]
+		}
+
+		if jsonType, ok := jsonColumnMap[column.ColumnName]; ok {
+			column.JsonType = getJsonType(jsonType)
+
+			// json aggregates are expressions, which carry no declared type
+			if column.Type == "" {
+				column.Type = "json"
+			}
+		}
+
+		columns = append(columns, column)
+	}
+
+	err = rows.Close()
+
+	if err != nil {
+		msg := msgWithFilename(args.source(), "failed to close introspection query result")
+
+		return i.Query{}, errorx.InternalError.Wrap(err, msg)
+	}
+
+	columns, err = i.AnnotateNullability(query, columns)
+
+	if err != nil {
+		msg := msgWithFilename(args.source(), "failed to parse nullability annotations")
+
+		return i.Query{}, errorx.Decorate(err, msg)
+	}
+
+	columns, err = i.AnnotateJsonTypes(query, columns)
+
+	if err != nil {
+		msg := msgWithFilename(args.source(), "failed to parse json type annotations")
+
+		return i.Query{}, errorx.Decorate(err, msg)
+	}
+
+	return newQuery(args, params, columns)
+}
+
+// newQuery names a query after its name header or, for a query spanning the
+// whole file, after the file.
+func newQuery(args QueryArgs, params []i.Column, columns []i.Column) (i.Query, error) {
+	if args.Name != "" {
+		pascalName, err := casing.PascalCase(args.Name)
+
+		if err != nil {
+			return i.Query{}, errorx.InternalError.Wrap(err, "failed to generate query name")
+		}
+
+		camelName, err := casing.CamelCase(args.Name)
+
+		if err != nil {
+			return i.Query{}, errorx.InternalError.Wrap(err, "failed to generate query name")
+		}
+
+		q := i.Query{
+			Filename:   args.Filename,
+			QueryName:  pascalName,
+			PascalName: pascalName,
+			CamelName:  camelName,
+			Params:     params,
+			Columns:    columns,
+			SourceDir:  args.GenDir,
+			Command:    args.Command,
+			Sql:        args.Query,
+		}
+
+		return q, nil
+	}
+
+	fileName, _ := utils.SplitFilename(args.Filename)
+
+	queryName, err := casing.PascalCase(fileName)
+
+	if err != nil {
+		msg := msgWithFilename(args.source(), "failed to generate query name")
+
+		return i.Query{}, errorx.Decorate(err, msg)
+	}
+
+	filename, err := casing.SnakeCase(args.Filename)
+
+	if err != nil {
+		msg := msgWithFilename(args.source(), "failed to generate query name")
+
+		return i.Query{}, errorx.Decorate(err, msg)
+	}
+
+	pascalName, err := casing.PascalCase(filename)
+
+	if err != nil {
+		return i.Query{}, errorx.InternalError.Wrap(err, "failed to generate query name")
+	}
+
+	camelName, err := casing.CamelCase(filename)
+
+	if err != nil {
+		return i.Query{}, errorx.InternalError.Wrap(err, "failed to generate query name")
+	}
+
+	q := i.Query{
+		Filename:   args.Filename,
+		QueryName:  queryName,
+		PascalName: pascalName,
+		CamelName:  camelName,
+		Params:     params,
+		Columns:    columns,
+		SourceDir:  args.GenDir,
+		Command:    args.Command,
+	}
+
+	return q, nil
+}
+
+// sampleQueryParams binds every param to null, except for integer params which
+// are bound to 0 since sqlite rejects a null limit or offset.
+func sampleQueryParams(params []i.Column) map[string]any {
+	sampleParams := make(map[string]any)
+
+	for _, param := range params {
+		sampleParams[param.ColumnName] = nil
+
+		if strings.Contains(strings.ToLower(param.Type), "int") {
+			sampleParams[param.ColumnName] = 0
+		}
+	}
+
+	return sampleParams
+}
+
+func generateIntrospectQuery(query string) (string, error) {
+	preparedQuery, err := prepare.PrepareQuery(query)
+
+	if err != nil {
+		return "", errorx.InternalError.Wrap(err, "failed to prepare query")
+	}
+
+	preparedQuery = strings.TrimRight(preparedQuery, "; \n\t")
+
+	tmpl, err := template.New("introspect").Parse(introspectQuerySql)
+
+	if err != nil {
+		return "", errorx.IllegalFormat.Wrap(err, "failed to parse introspect query template")
+	}
+
+	var introspectQueryBuffer bytes.Buffer
+
+	err = tmpl.Execute(
+		&introspectQueryBuffer,
+		map[string]interface{}{
+			"Query": preparedQuery,
+		},
+	)
+
+	if err != nil {
+		return "", errorx.IllegalFormat.Wrap(err, "failed to execute introspect query template")
+	}
+
+	introspectionQuery := introspectQueryBuffer.String()
+
+	return introspectionQuery, nil
+}
+
+// introspectDeclaredTypes runs the query without fetching any row, to read
+// the declared type of each result column. Expressions have no declared type.
+func introspectDeclaredTypes(tx *sqlx.Tx, query string, sampleParams map[string]any) ([]string, error) {
+	preparedQuery, err := prepare.PrepareQuery(query)
+
+	if err != nil {
+		return nil, errorx.InternalError.Wrap(err, "failed to prepare query")
+	}
+
+	rows, err := tx.NamedQuery(preparedQuery, sampleParams)
+
+	if err != nil {
+		return nil, errorx.InternalError.Wrap(err, "failed to execute query")
+	}
+
+	defer func(rows *sqlx.Rows) {
+		err := rows.Close()
+
+		if err != nil {
+			println(err.Error())
+		}
+	}(rows)
+
+	columnTypes, err := rows.ColumnTypes()
+
+	if err != nil {
+		return nil, errorx.InternalError.Wrap(err, "failed to read column types")
+	}
+
+	declaredTypes := make([]string, len(columnTypes))
+
+	for idx, columnType := range columnTypes {
+		declaredTypes[idx] = normalizeType(columnType.DatabaseTypeName())
+	}
+
+	return declaredTypes, nil
+}
+
+// normalizeType lowers a declared type and drops its size, e.g. VARCHAR(255)
+// becomes varchar, the same way the schema introspection does.
+func normalizeType(declaredType string) string {
+	base, _, _ := strings.Cut(declaredType, "(")
+
+	return strings.ToLower(strings.TrimSpace(base))
+}
+
+//go:embed query.sql
+var introspectQuerySql string
+
+type QueryArgs struct {
+	Query    string
+	Filename string
+	GenDir   string
+	Name     string
+	Command  string
+}
+
+// source identifies the query in error messages.
+func (args QueryArgs) source() string {
+	if args.Name == "" {
+		return args.Filename
+	}
+
+	return fmt.Sprintf("%s %s", args.Filename, args.Name)
+}
+
+func msgWithFilename(filename string, msg string) string {
+	return fmt.Sprintf("%s: %s", filename, msg)
+}
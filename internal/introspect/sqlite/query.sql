@@ -0,0 +1,16 @@
+--
+drop table if exists temp.sample_query_introspection;
+--
+create temp table sample_query_introspection as
+{{.Query}};
+--
+select
+ti.name as column_name,
+lower(ti.type) as type,
+'0' as type_id,
+false as is_array,
+false as is_sequence,
+true as nullable,
+false as "generated"
+from pragma_table_info('sample_query_introspection', 'temp') ti
+order by ti.cid;
@@ -0,0 +1,265 @@
+package sqlite
+
+import (
+	_ "embed"
+	"encoding/json"
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy"
+	"github.com/jmoiron/sqlx"
+	i "github.com/mvoorberg/sqlxgen/internal/introspect"
+	"github.com/mvoorberg/sqlxgen/internal/utils"
+	"github.com/mvoorberg/sqlxgen/internal/utils/array"
+	"github.com/mvoorberg/sqlxgen/internal/utils/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMsgWithFilename(t *testing.T) {
+	t.Parallel()
+
+	got := msgWithFilename("list-actors.sql", "failed to parse query params")
+
+	assert.Equal(t, "list-actors.sql: failed to parse query params", got)
+}
+
+func TestNormalizeType(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		declared string
+		want     string
+	}{
+		{declared: "VARCHAR(255)", want: "varchar"},
+		{declared: "NUMERIC(12, 2)", want: "numeric"},
+		{declared: "DATETIME", want: "datetime"},
+		{declared: "", want: ""},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.declared, func(t *testing.T) {
+			assert.Equal(t, testCase.want, normalizeType(testCase.declared))
+		})
+	}
+}
+
+func TestIntrospectQueries(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name     string
+		query    string
+		filename string
+	}
+
+	testCases := []testCase{
+		{
+			name:     "list actors",
+			filename: "list-actors.sql",
+			query:    listActorsQuery,
+		},
+		{
+			name:     "get movie",
+			filename: "get-movie.sql",
+			query:    getMovieQuery,
+		},
+	}
+
+	fd := fs.NewFakeFileDiscovery(
+		array.Map(
+			testCases,
+			func(testCase testCase, i int) fs.FakeDiscover {
+				return fs.FakeDiscover{
+					Content:  testCase.query,
+					Dir:      "fixtures",
+					Filename: testCase.filename,
+					FullPath: "fixtures/" + testCase.filename,
+				}
+			},
+		),
+	)
+
+	s := NewIntrospect(fd, IntrospectArgs{QueryDirs: []string{"fixtures"}})
+
+	db, err := utils.NewTempSqlite(t.TempDir(), schemaSql)
+
+	if err != nil {
+		t.Fatalf("failed to create sqlite db: %v", err)
+	}
+
+	defer func(db *sqlx.DB) {
+		err := db.Close()
+
+		if err != nil {
+			t.Fatalf("failed to close sqlite db: %v", err)
+		}
+	}(db)
+
+	tx, err := db.Beginx()
+
+	if err != nil {
+		t.Fatalf("failed to create transaction: %v", err)
+	}
+
+	defer func(tx *sqlx.Tx) {
+		err := tx.Rollback()
+
+		if err != nil {
+			t.Fatalf("failed to rollback transaction: %v", err)
+		}
+	}(tx)
+
+	queries, err := s.IntrospectQueries(tx)
+
+	assert.Nil(t, err)
+
+	assert.Equal(t, len(testCases), len(queries))
+
+	for i, query := range queries {
+		t.Run(testCases[i].name, func(t *testing.T) {
+			queryJson, err := json.MarshalIndent(query, "", "  ")
+
+			if err != nil {
+				t.Fatalf("failed to marshal query: %v", err)
+			}
+
+			cupaloy.SnapshotT(t, queryJson)
+		})
+	}
+}
+
+func TestIntrospectQueries_Named(t *testing.T) {
+	t.Parallel()
+
+	fd := fs.NewFakeFileDiscovery(
+		[]fs.FakeDiscover{
+			{
+				Content:  actorsQuery,
+				Dir:      "fixtures",
+				Filename: "actors.sql",
+				FullPath: "fixtures/actors.sql",
+			},
+		},
+	)
+
+	s := NewIntrospect(fd, IntrospectArgs{QueryDirs: []string{"fixtures"}})
+
+	db, err := utils.NewTempSqlite(t.TempDir(), schemaSql)
+
+	if err != nil {
+		t.Fatalf("failed to create sqlite db: %v", err)
+	}
+
+	defer func(db *sqlx.DB) {
+		err := db.Close()
+
+		if err != nil {
+			t.Fatalf("failed to close sqlite db: %v", err)
+		}
+	}(db)
+
+	tx, err := db.Beginx()
+
+	if err != nil {
+		t.Fatalf("failed to create transaction: %v", err)
+	}
+
+	defer func(tx *sqlx.Tx) {
+		err := tx.Rollback()
+
+		if err != nil {
+			t.Fatalf("failed to rollback transaction: %v", err)
+		}
+	}(tx)
+
+	queries, err := s.IntrospectQueries(tx)
+
+	assert.Nil(t, err)
+
+	names := []string{"GetActor", "ListActorMovies", "RenameActor"}
+
+	assert.Equal(t, len(names), len(queries))
+
+	for i, query := range queries {
+		t.Run(names[i], func(t *testing.T) {
+			assert.Equal(t, names[i], query.PascalName)
+
+			queryJson, err := json.MarshalIndent(query, "", "  ")
+
+			if err != nil {
+				t.Fatalf("failed to marshal query: %v", err)
+			}
+
+			cupaloy.SnapshotT(t, queryJson)
+		})
+	}
+}
+
+func TestIntrospectQueries_Dirs(t *testing.T) {
+	t.Parallel()
+
+	// the fake discovery finds the same file in every query dir
+	fd := fs.NewFakeFileDiscovery(
+		[]fs.FakeDiscover{
+			{
+				Content:  actorsQuery,
+				Dir:      "fixtures",
+				Filename: "actors.sql",
+				FullPath: "fixtures/actors.sql",
+			},
+		},
+	)
+
+	s := NewIntrospect(fd, IntrospectArgs{QueryDirs: []string{"actors", "movies"}})
+
+	db, err := utils.NewTempSqlite(t.TempDir(), schemaSql)
+
+	if err != nil {
+		t.Fatalf("failed to create sqlite db: %v", err)
+	}
+
+	defer func(db *sqlx.DB) {
+		err := db.Close()
+
+		if err != nil {
+			t.Fatalf("failed to close sqlite db: %v", err)
+		}
+	}(db)
+
+	tx, err := db.Beginx()
+
+	if err != nil {
+		t.Fatalf("failed to create transaction: %v", err)
+	}
+
+	defer func(tx *sqlx.Tx) {
+		err := tx.Rollback()
+
+		if err != nil {
+			t.Fatalf("failed to rollback transaction: %v", err)
+		}
+	}(tx)
+
+	queries, err := s.IntrospectQueries(tx)
+
+	assert.Nil(t, err)
+
+	names := array.Map(
+		queries,
+		func(query i.Query, _ int) string {
+			return query.PascalName
+		},
+	)
+
+	want := []string{"GetActor", "ListActorMovies", "RenameActor", "GetActor", "ListActorMovies", "RenameActor"}
+
+	assert.Equal(t, want, names)
+}
+
+//go:embed fixtures/actors.sql
+var actorsQuery string
+
+//go:embed fixtures/list-actors.sql
+var listActorsQuery string
+
+//go:embed fixtures/get-movie.sql
+var getMovieQuery string
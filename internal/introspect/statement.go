@@ -0,0 +1,132 @@
+package introspect
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/joomcode/errorx"
+)
+
+// QueryStatement is one query of a query file. A file without name headers
+// holds a single statement without a name, its command comes from an optional
+// `-- returns:` annotation.
+type QueryStatement struct {
+	Name    string
+	Command string
+	Sql     string
+}
+
+var queryCommands = []string{"one", "many", "exec", "execrows"}
+
+var nameHeaderRe = regexp.MustCompile(`(?m)^[ \t]*--[ \t]*name:[ \t]*(\S+)[ \t]+:(\S+)[ \t]*$`)
+
+var queryNameRe = regexp.MustCompile(`^[A-Za-z_]\w*$`)
+
+var commentLineRe = regexp.MustCompile(`(?m)^[ \t]*--.*$`)
+
+var returnsRe = regexp.MustCompile(`(?m)--[ \t]*returns:[ \t]*(\S+)`)
+
+// returnsCommands maps the values of a `-- returns:` annotation to commands.
+var returnsCommands = map[string]string{
+	"one":           "one",
+	"many":          "many",
+	"none":          "exec",
+	"rows-affected": "execrows",
+}
+
+// SplitQueryFile splits the content of a query file on its
+// `-- name: <Name> :one|:many|:exec|:execrows` headers.
+func SplitQueryFile(content string) ([]QueryStatement, error) {
+	headers := nameHeaderRe.FindAllStringSubmatchIndex(content, -1)
+
+	if len(headers) == 0 {
+		command, err := returnsCommand(content)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return []QueryStatement{{Command: command, Sql: strings.TrimSpace(content)}}, nil
+	}
+
+	preamble := commentLineRe.ReplaceAllString(content[:headers[0][0]], "")
+
+	if strings.TrimSpace(preamble) != "" {
+		return nil, errorx.IllegalFormat.New("query found before the first name header")
+	}
+
+	statements := make([]QueryStatement, 0, len(headers))
+
+	for idx, header := range headers {
+		name := content[header[2]:header[3]]
+
+		command := content[header[4]:header[5]]
+
+		end := len(content)
+
+		if idx+1 < len(headers) {
+			end = headers[idx+1][0]
+		}
+
+		sql := strings.TrimSpace(content[header[1]:end])
+
+		returns, err := returnsCommand(sql)
+
+		if err != nil {
+			return nil, errorx.Decorate(err, "query %s", name)
+		}
+
+		switch {
+		case !queryNameRe.MatchString(name):
+			return nil, errorx.IllegalFormat.New("invalid query name %s", name)
+
+		case !slices.Contains(queryCommands, command):
+			msg := fmt.Sprintf("unknown command :%s for query %s, want one of :%s", command, name, strings.Join(queryCommands, ", :"))
+
+			return nil, errorx.IllegalFormat.New(msg)
+
+		case strings.TrimSpace(commentLineRe.ReplaceAllString(sql, "")) == "":
+			return nil, errorx.IllegalFormat.New("query %s is empty", name)
+
+		case slices.ContainsFunc(statements, func(s QueryStatement) bool { return s.Name == name }):
+			return nil, errorx.IllegalFormat.New("query %s is declared more than once", name)
+
+		case returns != "" && returns != command:
+			return nil, errorx.IllegalFormat.New("query %s is declared :%s but annotated as :%s", name, command, returns)
+		}
+
+		statements = append(statements, QueryStatement{Name: name, Command: command, Sql: sql})
+	}
+
+	return statements, nil
+}
+
+// returnsCommand reads the `-- returns: one|many|none|rows-affected`
+// annotation of a query, an unannotated query has no command.
+func returnsCommand(sql string) (string, error) {
+	matches := returnsRe.FindAllStringSubmatch(sql, -1)
+
+	if len(matches) == 0 {
+		return "", nil
+	}
+
+	if len(matches) > 1 {
+		return "", errorx.IllegalFormat.New("more than one returns annotation")
+	}
+
+	command, ok := returnsCommands[matches[0][1]]
+
+	if !ok {
+		return "", errorx.IllegalFormat.New("unknown returns annotation %s, want one of one, many, none, rows-affected", matches[0][1])
+	}
+
+	return command, nil
+}
+
+// ReturnsRows reports whether a query command produces a result set, exec
+// queries are not introspected for columns.
+func ReturnsRows(command string) bool {
+	return command != "exec" && command != "execrows"
+}
@@ -0,0 +1,131 @@
+package introspect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitQueryFile(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		content string
+		want    []QueryStatement
+		err     string
+	}{
+		{
+			name:    "should keep a file without headers whole",
+			content: "\nselect * from actors where id = :id; -- :id type: int\n",
+			want: []QueryStatement{
+				{Sql: "select * from actors where id = :id; -- :id type: int"},
+			},
+		},
+		{
+			name: "should split named queries",
+			content: `-- queries on actors
+
+-- name: GetActor :one
+select * from actors where id = :id; -- :id type: int
+
+--name:ListActors :many
+select * from actors;
+
+  -- name: DeleteActor   :exec
+delete from actors where id = :id; -- :id type: int
+-- name: RenameActor :execrows
+update actors set name = :name where id = :id;
+`,
+			want: []QueryStatement{
+				{Name: "GetActor", Command: "one", Sql: "select * from actors where id = :id; -- :id type: int"},
+				{Name: "ListActors", Command: "many", Sql: "select * from actors;"},
+				{Name: "DeleteActor", Command: "exec", Sql: "delete from actors where id = :id; -- :id type: int"},
+				{Name: "RenameActor", Command: "execrows", Sql: "update actors set name = :name where id = :id;"},
+			},
+		},
+		{
+			name:    "should read the returns annotation of a whole file",
+			content: "-- returns: rows-affected\ndelete from actors where id = :id; -- :id type: int\n",
+			want: []QueryStatement{
+				{Command: "execrows", Sql: "-- returns: rows-affected\ndelete from actors where id = :id; -- :id type: int"},
+			},
+		},
+		{
+			name:    "should accept a returns annotation matching the header",
+			content: "-- name: GetOne :one\n-- returns: one\nselect 1;",
+			want: []QueryStatement{
+				{Name: "GetOne", Command: "one", Sql: "-- returns: one\nselect 1;"},
+			},
+		},
+		{
+			name:    "should reject a returns annotation contradicting the header",
+			content: "-- name: GetOne :one\n-- returns: many\nselect 1;",
+			err:     "query GetOne is declared :one but annotated as :many",
+		},
+		{
+			name:    "should reject an unknown returns annotation",
+			content: "-- returns: some\nselect 1;",
+			err:     "unknown returns annotation some",
+		},
+		{
+			name:    "should reject a query before the first header",
+			content: "select 1;\n-- name: GetOne :one\nselect 1;",
+			err:     "query found before the first name header",
+		},
+		{
+			name:    "should reject an unknown command",
+			content: "-- name: GetOne :single\nselect 1;",
+			err:     "unknown command :single for query GetOne",
+		},
+		{
+			name:    "should reject an invalid name",
+			content: "-- name: get-one :one\nselect 1;",
+			err:     "invalid query name get-one",
+		},
+		{
+			name:    "should reject an empty query",
+			content: "-- name: GetOne :one\n-- nothing here\n-- name: GetTwo :one\nselect 2;",
+			err:     "query GetOne is empty",
+		},
+		{
+			name:    "should reject a duplicate name",
+			content: "-- name: GetOne :one\nselect 1;\n-- name: GetOne :many\nselect 1;",
+			err:     "query GetOne is declared more than once",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := SplitQueryFile(testCase.content)
+
+			if testCase.err != "" {
+				assert.ErrorContains(t, err, testCase.err)
+
+				return
+			}
+
+			assert.Nil(t, err)
+
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
+func TestReturnsRows(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, ReturnsRows(""))
+
+	assert.True(t, ReturnsRows("one"))
+
+	assert.True(t, ReturnsRows("many"))
+
+	assert.False(t, ReturnsRows("exec"))
+
+	assert.False(t, ReturnsRows("execrows"))
+}
@@ -8,9 +8,11 @@ import (
 )
 
 type Table struct {
-	SchemaName string  `db:"schema_name" json:"schema_name"`
-	TableName  string  `db:"table_name" json:"table_name"`
-	Columns    Columns `db:"columns" json:"columns"`
+	SchemaName  string      `db:"schema_name" json:"schema_name"`
+	TableName   string      `db:"table_name" json:"table_name"`
+	Columns     Columns     `db:"columns" json:"columns"`
+	ForeignKeys ForeignKeys `db:"foreign_keys" json:"foreign_keys"`
+	UniqueKeys  UniqueKeys  `db:"unique_keys" json:"unique_keys"`
 }
 
 func (table *Table) String() string {
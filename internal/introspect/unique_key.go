@@ -0,0 +1,66 @@
+package introspect
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mvoorberg/sqlxgen/internal/utils/array"
+)
+
+// UniqueKey is a unique constraint or a unique index over plain columns,
+// the primary key is not part of the unique keys.
+type UniqueKey struct {
+	ConstraintName string   `db:"constraint_name" json:"constraint_name"`
+	Columns        []string `db:"columns" json:"columns"`
+}
+
+func (uk *UniqueKey) String() string {
+	content := strings.Join(
+		[]string{
+			fmt.Sprintf("ConstraintName: %v", uk.ConstraintName),
+			fmt.Sprintf("Columns: %v", uk.Columns),
+		},
+		", ",
+	)
+
+	return fmt.Sprintf("UniqueKey{%s}", content)
+}
+
+type UniqueKeys []UniqueKey
+
+func (uks *UniqueKeys) String() string {
+	content := strings.Join(
+		array.Map(
+			*uks,
+			func(uk UniqueKey, _ int) string {
+				return (&uk).String()
+			},
+		),
+		", ",
+	)
+
+	return fmt.Sprintf("[%s]", content)
+}
+
+func (uks *UniqueKeys) Value() (driver.Value, error) {
+	return json.Marshal(uks)
+}
+
+func (uks *UniqueKeys) Scan(value interface{}) error {
+	if value == nil {
+		*uks = make(UniqueKeys, 0)
+
+		return nil
+	}
+
+	buffer, ok := value.([]byte)
+
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(buffer, uks)
+}
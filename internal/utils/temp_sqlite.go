@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"path"
+
+	"github.com/jmoiron/sqlx"
+	_ "modernc.org/sqlite"
+)
+
+// NewTempSqlite creates a sqlite database file in dir and applies the schema.
+func NewTempSqlite(dir string, schema string) (*sqlx.DB, error) {
+	db, err := sqlx.Connect("sqlite", path.Join(dir, "sqlite.db"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = db.Exec(schema)
+
+	if err != nil {
+		_ = db.Close()
+
+		return nil, err
+	}
+
+	return db, nil
+}
@@ -0,0 +1,171 @@
+package writer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/joomcode/errorx"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+type CheckStatus string
+
+const (
+	CheckUnchanged CheckStatus = "unchanged"
+	CheckChanged   CheckStatus = "changed"
+	CheckCreated   CheckStatus = "created"
+)
+
+// CheckWriter compares the generated content with the file on disk instead
+// of writing it.
+type CheckWriter struct {
+	FullPath string
+	Content  string
+	Status   CheckStatus
+	Diff     string
+	OnWrite  func(cw *CheckWriter) error
+}
+
+func (c *CheckWriter) Write() error {
+	existing, err := os.ReadFile(c.FullPath)
+
+	switch {
+	case os.IsNotExist(err):
+		c.Status = CheckCreated
+
+	case err != nil:
+		return errorx.IllegalState.Wrap(err, "unable to read existing generated file %s", c.FullPath)
+
+	case string(existing) == c.Content:
+		c.Status = CheckUnchanged
+
+	default:
+		c.Status = CheckChanged
+
+		c.Diff, err = difflib.GetUnifiedDiffString(
+			difflib.UnifiedDiff{
+				A:        difflib.SplitLines(string(existing)),
+				B:        difflib.SplitLines(c.Content),
+				FromFile: c.FullPath,
+				ToFile:   c.FullPath,
+				Context:  3,
+			},
+		)
+
+		if err != nil {
+			return errorx.InternalError.Wrap(err, "unable to diff generated file %s", c.FullPath)
+		}
+	}
+
+	if c.OnWrite != nil {
+		return c.OnWrite(c)
+	}
+
+	return nil
+}
+
+type CheckWriters struct {
+	Writers []*CheckWriter
+}
+
+func (cws *CheckWriters) Creator(fullPath string, content string) Writer {
+	cw := &CheckWriter{
+		FullPath: fullPath,
+		Content:  content,
+		OnWrite: func(cw *CheckWriter) error {
+			cws.Writers = append(cws.Writers, cw)
+
+			return nil
+		},
+	}
+
+	return cw
+}
+
+// Orphans lists the generated files, in the directories written to, that the
+// generation no longer produces.
+func (cws *CheckWriters) Orphans() ([]string, error) {
+	written := make(map[string]bool)
+
+	dirs := make([]string, 0)
+
+	for _, cw := range cws.Writers {
+		written[filepath.Clean(cw.FullPath)] = true
+
+		dir := filepath.Dir(filepath.Clean(cw.FullPath))
+
+		if !slices.Contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	orphans := make([]string, 0)
+
+	for _, dir := range dirs {
+		matches, err := filepath.Glob(filepath.Join(dir, "*.gen.go"))
+
+		if err != nil {
+			return nil, errorx.IllegalState.Wrap(err, "unable to list generated files in %s", dir)
+		}
+
+		for _, match := range matches {
+			if !written[match] {
+				orphans = append(orphans, match)
+			}
+		}
+	}
+
+	slices.Sort(orphans)
+
+	return orphans, nil
+}
+
+// Report prints a unified diff per changed file and the files that would be
+// created or are orphaned, it returns whether anything is stale.
+func (cws *CheckWriters) Report(out io.Writer) (bool, error) {
+	orphans, err := cws.Orphans()
+
+	if err != nil {
+		return false, err
+	}
+
+	created := make([]string, 0)
+
+	changed := 0
+
+	for _, cw := range cws.Writers {
+		switch cw.Status {
+		case CheckChanged:
+			changed++
+
+			_, _ = fmt.Fprint(out, cw.Diff)
+
+		case CheckCreated:
+			created = append(created, cw.FullPath)
+		}
+	}
+
+	writeList(out, "would be created", created)
+
+	writeList(out, "would be orphaned", orphans)
+
+	return changed > 0 || len(created) > 0 || len(orphans) > 0, nil
+}
+
+func writeList(out io.Writer, title string, fullPaths []string) {
+	if len(fullPaths) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintf(out, "%s:\n  %s\n", title, strings.Join(fullPaths, "\n  "))
+}
+
+func NewCheckWriters() *CheckWriters {
+	return &CheckWriters{
+		Writers: make([]*CheckWriter, 0),
+	}
+}
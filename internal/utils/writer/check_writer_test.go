@@ -0,0 +1,158 @@
+package writer
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckWriter_Write(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile(t, path.Join(dir, "unchanged.gen.go"), "lorem ipsum\ndolor sit amet\n")
+
+	writeFile(t, path.Join(dir, "changed.gen.go"), "lorem ipsum\ndolor sit amet\n")
+
+	testCases := []struct {
+		testName string
+		fullPath string
+		content  string
+		status   CheckStatus
+		diff     string
+	}{
+		{
+			testName: "unchanged file",
+			fullPath: path.Join(dir, "unchanged.gen.go"),
+			content:  "lorem ipsum\ndolor sit amet\n",
+			status:   CheckUnchanged,
+		},
+		{
+			testName: "changed file",
+			fullPath: path.Join(dir, "changed.gen.go"),
+			content:  "lorem ipsum\nconsectetur\n",
+			status:   CheckChanged,
+			diff:     "-dolor sit amet\n+consectetur\n",
+		},
+		{
+			testName: "created file",
+			fullPath: path.Join(dir, "created.gen.go"),
+			content:  "lorem ipsum\n",
+			status:   CheckCreated,
+		},
+	}
+
+	cws := NewCheckWriters()
+
+	for i, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			writer := cws.Creator(testCase.fullPath, testCase.content)
+
+			err := writer.Write()
+
+			assert.Nil(t, err)
+
+			pen := cws.Writers[i]
+
+			assert.Equal(t, testCase.status, pen.Status)
+
+			assert.Contains(t, pen.Diff, testCase.diff)
+
+			_, err = os.Stat(path.Join(dir, "created.gen.go"))
+
+			assert.True(t, os.IsNotExist(err), "check must not write files")
+		})
+	}
+}
+
+func TestCheckWriters_Report(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile(t, path.Join(dir, "store.gen.go"), "package store\n")
+
+	writeFile(t, path.Join(dir, "orphan.gen.go"), "package store\n")
+
+	writeFile(t, path.Join(dir, "hand_written.go"), "package store\n")
+
+	testCases := []struct {
+		testName string
+		files    map[string]string
+		stale    bool
+		report   []string
+	}{
+		{
+			testName: "orphaned file",
+			files: map[string]string{
+				"store.gen.go": "package store\n",
+			},
+			stale:  true,
+			report: []string{"would be orphaned:\n  " + path.Join(dir, "orphan.gen.go")},
+		},
+		{
+			testName: "created and changed file",
+			files: map[string]string{
+				"store.gen.go":  "package models\n",
+				"orphan.gen.go": "package store\n",
+				"models.gen.go": "package models\n",
+			},
+			stale: true,
+			report: []string{
+				"-package store\n+package models\n",
+				"would be created:\n  " + path.Join(dir, "models.gen.go"),
+			},
+		},
+		{
+			testName: "up to date",
+			files: map[string]string{
+				"store.gen.go":  "package store\n",
+				"orphan.gen.go": "package store\n",
+			},
+			stale:  false,
+			report: []string{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			cws := NewCheckWriters()
+
+			for name, content := range testCase.files {
+				err := cws.Creator(path.Join(dir, name), content).Write()
+
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var out bytes.Buffer
+
+			stale, err := cws.Report(&out)
+
+			assert.Nil(t, err)
+
+			assert.Equal(t, testCase.stale, stale)
+
+			for _, line := range testCase.report {
+				assert.Contains(t, out.String(), line)
+			}
+
+			if !testCase.stale {
+				assert.Empty(t, out.String())
+			}
+		})
+	}
+}
+
+func writeFile(t *testing.T, fullPath string, content string) {
+	err := os.WriteFile(fullPath, []byte(content), 0644)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
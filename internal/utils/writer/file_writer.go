@@ -2,6 +2,7 @@ package writer
 
 import (
 	"os"
+	"path/filepath"
 
 	"github.com/joomcode/errorx"
 )
@@ -11,8 +12,16 @@ type FileWriter struct {
 	Content  string
 }
 
+// Write creates the directory of the file when missing, the other writers
+// leave the disk untouched.
 func (f *FileWriter) Write() error {
-	err := os.Remove(f.FullPath)
+	err := os.MkdirAll(filepath.Dir(f.FullPath), 0755)
+
+	if err != nil {
+		return errorx.InitializationFailed.Wrap(err, "unable to create directory for %s", f.FullPath)
+	}
+
+	err = os.Remove(f.FullPath)
 
 	if err != nil && !os.IsNotExist(err) {
 		return errorx.IllegalState.Wrap(err, "unable to remove existing generated query file")
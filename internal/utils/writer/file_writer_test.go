@@ -37,6 +37,14 @@ func TestFileWriter_Write(t *testing.T) {
 			},
 			error: nil,
 		},
+		{
+			testName: "missing directory",
+			fields: fields{
+				FullPath: path.Join(dir, "gen/store/test3.txt"),
+				Content:  "lorem ipsum dolor sit amet",
+			},
+			error: nil,
+		},
 	}
 
 	for _, testCase := range testCases {